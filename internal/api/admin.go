@@ -0,0 +1,369 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+type adminClaimsContextKey struct{}
+
+// AdminClaims are the claims carried by the JWT issued on bootstrap or by an existing
+// super-admin, and validated on every request under /admin.
+type AdminClaims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role"`
+}
+
+// AdminHandlers exposes the CRUD endpoints mounted under /admin. It is kept separate from
+// Server because it is not part of the generated StrictServerInterface: the admin API is not
+// published in the public OpenAPI spec and is routed with plain chi handlers instead. It only
+// ever talks to ports.AdminService, the same way every StrictServerInterface controller only
+// ever talks to a ports.*Service — persistence and JWT minting are not this layer's job.
+type AdminHandlers struct {
+	cfg     *config.Configuration
+	service ports.AdminService
+}
+
+// NewAdminHandlers is an AdminHandlers constructor.
+func NewAdminHandlers(cfg *config.Configuration, service ports.AdminService) *AdminHandlers {
+	return &AdminHandlers{cfg: cfg, service: service}
+}
+
+// RegisterAdmin mounts the /admin subtree on mux, behind the JWT auth middleware.
+func RegisterAdmin(mux *chi.Mux, h *AdminHandlers) {
+	mux.Route("/admin", func(r chi.Router) {
+		r.Use(adminAuthMiddleware(h.cfg, h.service))
+
+		r.Route("/issuers", func(r chi.Router) {
+			r.Post("/", h.createIssuer)
+			r.Get("/", h.listIssuers)
+			r.Patch("/{id}", h.updateIssuer)
+			r.Delete("/{id}", h.deleteIssuer)
+		})
+
+		r.Route("/schemas", func(r chi.Router) {
+			r.Post("/", h.createSchema)
+			r.Get("/", h.listSchemas)
+			r.Delete("/{id}", h.deleteSchema)
+		})
+
+		r.Route("/users", func(r chi.Router) {
+			r.Use(requireSuperAdmin)
+			r.Post("/", h.createAdminUser)
+			r.Get("/", h.listAdminUsers)
+			r.Delete("/{id}", h.deleteAdminUser)
+		})
+	})
+}
+
+// adminAuthMiddleware validates the bearer JWT on every /admin request and injects the parsed
+// claims into the request context so handlers can authorize by role.
+//
+// jwt.WithValidMethods pins the accepted algorithm to HS256: without it, a keyfunc that always
+// returns the HMAC key is the classic algorithm-confusion hole, since a token presenting "alg":
+// "none" (or any asymmetric alg whose public half an attacker can get) would otherwise be
+// accepted without ever checking the signature against the configured key.
+//
+// A valid signature only proves the token was minted by this node at some point in the past;
+// it says nothing about whether the admin user behind it has since been deactivated. So every
+// request also re-looks-up claims.Subject through service and rejects unless the user is still
+// domain.AdminUserActive - otherwise a deactivated admin would keep full access for up to
+// adminJWTTTL after deletion, defeating the point of soft-deleting instead of dropping the row.
+func adminAuthMiddleware(cfg *config.Configuration, service ports.AdminService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if token == "" {
+				jsonError(w, http.StatusUnauthorized, "missing bearer token")
+				return
+			}
+
+			claims := &AdminClaims{}
+			_, err := jwt.ParseWithClaims(token, claims, func(_ *jwt.Token) (interface{}, error) {
+				return []byte(cfg.Admin.SigningKey), nil
+			}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+			if err != nil {
+				jsonError(w, http.StatusUnauthorized, "invalid token")
+				return
+			}
+
+			if claims.Role != ports.AdminRoleSuper && claims.Role != ports.AdminRoleIssuerAdmin {
+				jsonError(w, http.StatusForbidden, "role is not allowed to access the admin API")
+				return
+			}
+
+			subject, err := uuid.Parse(claims.Subject)
+			if err != nil {
+				jsonError(w, http.StatusUnauthorized, "invalid token")
+				return
+			}
+
+			user, err := service.GetAdminUser(r.Context(), subject)
+			if err != nil || user.Status != domain.AdminUserActive {
+				jsonError(w, http.StatusUnauthorized, "admin user is no longer active")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), adminClaimsContextKey{}, claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func requireSuperAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := r.Context().Value(adminClaimsContextKey{}).(*AdminClaims)
+		if !ok || claims.Role != ports.AdminRoleSuper {
+			jsonError(w, http.StatusForbidden, "only a super admin can manage admin users")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AdminIssuerRequest is the payload to create or update an issuer the node is allowed to sign for.
+type AdminIssuerRequest struct {
+	DID  string `json:"did"`
+	Name string `json:"name"`
+}
+
+func (h *AdminHandlers) createIssuer(w http.ResponseWriter, r *http.Request) {
+	var req AdminIssuerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	issuer, err := h.service.CreateIssuer(r.Context(), req.DID, req.Name)
+	if err != nil {
+		if errors.Is(err, repositories.ErrAdminDuplicateName) {
+			jsonError(w, http.StatusConflict, "an issuer with that name already exists")
+			return
+		}
+		jsonError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, issuer)
+}
+
+func (h *AdminHandlers) listIssuers(w http.ResponseWriter, r *http.Request) {
+	issuers, err := h.service.GetIssuers(r.Context())
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, issuers)
+}
+
+func (h *AdminHandlers) updateIssuer(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, "invalid issuer id")
+		return
+	}
+
+	var req AdminIssuerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	issuer, err := h.service.UpdateIssuer(r.Context(), id, req.DID, req.Name)
+	if err != nil {
+		if errors.Is(err, repositories.ErrAdminNotFound) {
+			jsonError(w, http.StatusNotFound, "issuer not found")
+			return
+		}
+		if errors.Is(err, repositories.ErrAdminDuplicateName) {
+			jsonError(w, http.StatusConflict, "an issuer with that name already exists")
+			return
+		}
+		jsonError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, issuer)
+}
+
+func (h *AdminHandlers) deleteIssuer(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, "invalid issuer id")
+		return
+	}
+
+	if err := h.service.DeleteIssuer(r.Context(), id); err != nil {
+		if errors.Is(err, repositories.ErrAdminNotFound) {
+			jsonError(w, http.StatusNotFound, "issuer not found")
+			return
+		}
+		jsonError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AdminSchemaRequest whitelists a schema URL together with the credential types and JSON-LD
+// contexts the node is allowed to issue against it.
+type AdminSchemaRequest struct {
+	URL     string   `json:"url"`
+	Types   []string `json:"types"`
+	Context []string `json:"context"`
+}
+
+func (h *AdminHandlers) createSchema(w http.ResponseWriter, r *http.Request) {
+	var req AdminSchemaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	schema, err := h.service.CreateSchemaWhitelistEntry(r.Context(), req.URL, req.Types, req.Context)
+	if err != nil {
+		if errors.Is(err, repositories.ErrAdminDuplicateName) {
+			jsonError(w, http.StatusConflict, "schema is already whitelisted")
+			return
+		}
+		jsonError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, schema)
+}
+
+func (h *AdminHandlers) listSchemas(w http.ResponseWriter, r *http.Request) {
+	schemas, err := h.service.GetSchemaWhitelist(r.Context())
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, schemas)
+}
+
+func (h *AdminHandlers) deleteSchema(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, "invalid schema id")
+		return
+	}
+
+	if err := h.service.DeleteSchemaWhitelistEntry(r.Context(), id); err != nil {
+		if errors.Is(err, repositories.ErrAdminNotFound) {
+			jsonError(w, http.StatusNotFound, "schema not found")
+			return
+		}
+		jsonError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AdminUserRequest creates a new admin user.
+type AdminUserRequest struct {
+	Name         string `json:"name"`
+	IsSuperAdmin bool   `json:"isSuperAdmin"`
+}
+
+func (h *AdminHandlers) createAdminUser(w http.ResponseWriter, r *http.Request) {
+	var req AdminUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	user, token, err := h.service.CreateAdminUser(r.Context(), req.Name, req.IsSuperAdmin)
+	if err != nil {
+		if errors.Is(err, repositories.ErrAdminDuplicateName) {
+			jsonError(w, http.StatusConflict, "an admin user with that name already exists")
+			return
+		}
+		jsonError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, struct {
+		ID           uuid.UUID `json:"id"`
+		Name         string    `json:"name"`
+		IsSuperAdmin bool      `json:"isSuperAdmin"`
+		Status       string    `json:"status"`
+		Token        string    `json:"token"`
+	}{user.ID, user.Name, user.IsSuperAdmin, string(user.Status), token})
+}
+
+func (h *AdminHandlers) listAdminUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := h.service.GetAdminUsers(r.Context())
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, users)
+}
+
+// deleteAdminUser soft-deletes the admin user by moving it to the inactive status rather than
+// dropping the row, so JWTs already issued to it can still be audited after the fact.
+func (h *AdminHandlers) deleteAdminUser(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, "invalid admin user id")
+		return
+	}
+
+	if err := h.service.DeactivateAdminUser(r.Context(), id); err != nil {
+		if errors.Is(err, repositories.ErrAdminNotFound) {
+			jsonError(w, http.StatusNotFound, "admin user not found")
+			return
+		}
+		jsonError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// BootstrapSuperAdmin creates the first super admin and prints its JWT once, the same way
+// stepped-ca prints its provisioner password on first run. It is a no-op once the admin table
+// already has at least one user. Called once by NewRouter, before the /admin subtree is mounted.
+func BootstrapSuperAdmin(ctx context.Context, service ports.AdminService) error {
+	token, err := service.Bootstrap(ctx)
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return nil
+	}
+
+	log.Printf("generated initial super admin token (store it now, it will not be shown again): %s", token)
+	return nil
+}
+
+// adminErrorResponse is the error body returned by the plain-chi admin handlers. It mirrors the
+// shape of the generated N400/N404/N500 JSON responses used by the rest of the API.
+type adminErrorResponse struct {
+	Message string `json:"message"`
+}
+
+func jsonError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, adminErrorResponse{Message: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
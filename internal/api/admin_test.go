@@ -0,0 +1,307 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/repositories"
+)
+
+func TestAdminAuthMiddleware_RejectsTokenSignedWithUnexpectedAlgorithm(t *testing.T) {
+	cfg := &config.Configuration{Admin: config.Admin{SigningKey: "super-secret"}}
+
+	// "none" algorithm: no signature at all. A keyfunc that blindly returns the HMAC key without
+	// pinning the accepted algorithm would previously let this through.
+	none := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"role": ports.AdminRoleSuper})
+	noneToken, err := none.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	handler := adminAuthMiddleware(cfg, &fakeAdminService{})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/issuers", nil)
+	req.Header.Set("Authorization", "Bearer "+noneToken)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAdminAuthMiddleware_AcceptsValidHS256Token(t *testing.T) {
+	cfg := &config.Configuration{Admin: config.Admin{SigningKey: "super-secret"}}
+	user := &domain.AdminUser{ID: uuid.New(), Status: domain.AdminUserActive}
+	svc := &fakeAdminService{users: []*domain.AdminUser{user}}
+
+	valid := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"role": ports.AdminRoleSuper, "sub": user.ID.String()})
+	validToken, err := valid.SignedString([]byte(cfg.Admin.SigningKey))
+	require.NoError(t, err)
+
+	called := false
+	handler := adminAuthMiddleware(cfg, svc)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/issuers", nil)
+	req.Header.Set("Authorization", "Bearer "+validToken)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, called)
+}
+
+// TestAdminAuthMiddleware_RejectsDeactivatedAdmin is the regression test for the soft-delete
+// feature: a signature check alone only proves the token was minted at some point in the past, so
+// the middleware must re-look-up the subject's current status on every request instead of trusting
+// the JWT for its full adminJWTTTL.
+func TestAdminAuthMiddleware_RejectsDeactivatedAdmin(t *testing.T) {
+	cfg := &config.Configuration{Admin: config.Admin{SigningKey: "super-secret"}}
+	user := &domain.AdminUser{ID: uuid.New(), Status: domain.AdminUserInactive}
+	svc := &fakeAdminService{users: []*domain.AdminUser{user}}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"role": ports.AdminRoleSuper, "sub": user.ID.String()})
+	signedToken, err := token.SignedString([]byte(cfg.Admin.SigningKey))
+	require.NoError(t, err)
+
+	called := false
+	handler := adminAuthMiddleware(cfg, svc)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/issuers", nil)
+	req.Header.Set("Authorization", "Bearer "+signedToken)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.False(t, called, "handler must not run for a deactivated admin")
+}
+
+// TestAdminAuthMiddleware_RejectsTokenForDeletedAdmin covers a token whose subject no longer
+// exists at all, e.g. the row was removed outright rather than soft-deleted.
+func TestAdminAuthMiddleware_RejectsTokenForDeletedAdmin(t *testing.T) {
+	cfg := &config.Configuration{Admin: config.Admin{SigningKey: "super-secret"}}
+	svc := &fakeAdminService{}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"role": ports.AdminRoleSuper, "sub": uuid.New().String()})
+	signedToken, err := token.SignedString([]byte(cfg.Admin.SigningKey))
+	require.NoError(t, err)
+
+	handler := adminAuthMiddleware(cfg, svc)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/issuers", nil)
+	req.Header.Set("Authorization", "Bearer "+signedToken)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+// fakeAdminService is an in-memory ports.AdminService used only by this test. Each Err field, if
+// set, is returned verbatim by the matching method instead of a successful result - this is what
+// lets a test drive the repositories.ErrAdminDuplicateName/ErrAdminNotFound -> 409/404 mapping in
+// AdminHandlers without a real database.
+type fakeAdminService struct {
+	users []*domain.AdminUser
+
+	createIssuerErr        error
+	updateIssuerErr        error
+	deleteIssuerErr        error
+	createSchemaErr        error
+	deleteSchemaErr        error
+	createAdminUserErr     error
+	getAdminUserErr        error
+	deactivateAdminUserErr error
+}
+
+func (f *fakeAdminService) CreateIssuer(_ context.Context, did, name string) (*domain.AdminIssuer, error) {
+	if f.createIssuerErr != nil {
+		return nil, f.createIssuerErr
+	}
+	return &domain.AdminIssuer{ID: uuid.New(), DID: did, Name: name}, nil
+}
+func (f *fakeAdminService) GetIssuers(context.Context) ([]*domain.AdminIssuer, error) {
+	return nil, nil
+}
+func (f *fakeAdminService) UpdateIssuer(_ context.Context, id uuid.UUID, did, name string) (*domain.AdminIssuer, error) {
+	if f.updateIssuerErr != nil {
+		return nil, f.updateIssuerErr
+	}
+	return &domain.AdminIssuer{ID: id, DID: did, Name: name}, nil
+}
+func (f *fakeAdminService) DeleteIssuer(context.Context, uuid.UUID) error { return f.deleteIssuerErr }
+
+func (f *fakeAdminService) CreateSchemaWhitelistEntry(_ context.Context, url string, types, ldContext []string) (*domain.SchemaWhitelistEntry, error) {
+	if f.createSchemaErr != nil {
+		return nil, f.createSchemaErr
+	}
+	return &domain.SchemaWhitelistEntry{ID: uuid.New(), URL: url, Types: types, Context: ldContext}, nil
+}
+func (f *fakeAdminService) GetSchemaWhitelist(context.Context) ([]*domain.SchemaWhitelistEntry, error) {
+	return nil, nil
+}
+func (f *fakeAdminService) DeleteSchemaWhitelistEntry(context.Context, uuid.UUID) error {
+	return f.deleteSchemaErr
+}
+
+func (f *fakeAdminService) CreateAdminUser(_ context.Context, name string, isSuperAdmin bool) (*domain.AdminUser, string, error) {
+	if f.createAdminUserErr != nil {
+		return nil, "", f.createAdminUserErr
+	}
+	user := &domain.AdminUser{ID: uuid.New(), Name: name, IsSuperAdmin: isSuperAdmin, Status: domain.AdminUserActive}
+	f.users = append(f.users, user)
+	return user, "minted-token", nil
+}
+func (f *fakeAdminService) GetAdminUsers(context.Context) ([]*domain.AdminUser, error) {
+	return f.users, nil
+}
+func (f *fakeAdminService) GetAdminUser(_ context.Context, id uuid.UUID) (*domain.AdminUser, error) {
+	if f.getAdminUserErr != nil {
+		return nil, f.getAdminUserErr
+	}
+	for _, user := range f.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, repositories.ErrAdminNotFound
+}
+func (f *fakeAdminService) DeactivateAdminUser(_ context.Context, id uuid.UUID) error {
+	if f.deactivateAdminUserErr != nil {
+		return f.deactivateAdminUserErr
+	}
+	for _, user := range f.users {
+		if user.ID == id {
+			user.Status = domain.AdminUserInactive
+			return nil
+		}
+	}
+	return repositories.ErrAdminNotFound
+}
+
+func (f *fakeAdminService) Bootstrap(ctx context.Context) (string, error) {
+	if len(f.users) > 0 {
+		return "", nil
+	}
+	_, token, err := f.CreateAdminUser(ctx, "bootstrap", true)
+	return token, err
+}
+
+func TestBootstrapSuperAdmin_CreatesExactlyOneSuperAdminWhenTableIsEmpty(t *testing.T) {
+	svc := &fakeAdminService{}
+
+	require.NoError(t, BootstrapSuperAdmin(context.Background(), svc))
+	require.Len(t, svc.users, 1)
+	require.True(t, svc.users[0].IsSuperAdmin)
+
+	// A second call must be a no-op: the admin table is no longer empty.
+	require.NoError(t, BootstrapSuperAdmin(context.Background(), svc))
+	require.Len(t, svc.users, 1)
+}
+
+// newTestAdminMux mounts h's CRUD handlers directly, without adminAuthMiddleware, since that is
+// already covered by the JWT tests above and would only add noise to every request built here.
+func newTestAdminMux(h *AdminHandlers) *chi.Mux {
+	mux := chi.NewRouter()
+	mux.Post("/issuers", h.createIssuer)
+	mux.Patch("/issuers/{id}", h.updateIssuer)
+	mux.Delete("/issuers/{id}", h.deleteIssuer)
+	mux.Post("/schemas", h.createSchema)
+	mux.Delete("/schemas/{id}", h.deleteSchema)
+	mux.Post("/users", h.createAdminUser)
+	mux.Delete("/users/{id}", h.deleteAdminUser)
+	return mux
+}
+
+func TestAdminHandlers_CreateIssuer_ReturnsConflictOnDuplicateName(t *testing.T) {
+	svc := &fakeAdminService{createIssuerErr: repositories.ErrAdminDuplicateName}
+	mux := newTestAdminMux(NewAdminHandlers(&config.Configuration{}, svc))
+
+	req := httptest.NewRequest(http.MethodPost, "/issuers", bytes.NewBufferString(`{"did":"did:iden3:x","name":"acme"}`))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestAdminHandlers_UpdateIssuer_ReturnsNotFoundWhenMissing(t *testing.T) {
+	svc := &fakeAdminService{updateIssuerErr: repositories.ErrAdminNotFound}
+	mux := newTestAdminMux(NewAdminHandlers(&config.Configuration{}, svc))
+
+	req := httptest.NewRequest(http.MethodPatch, "/issuers/"+uuid.New().String(), bytes.NewBufferString(`{"did":"did:iden3:x","name":"acme"}`))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAdminHandlers_DeleteIssuer_ReturnsNotFoundWhenMissing(t *testing.T) {
+	svc := &fakeAdminService{deleteIssuerErr: repositories.ErrAdminNotFound}
+	mux := newTestAdminMux(NewAdminHandlers(&config.Configuration{}, svc))
+
+	req := httptest.NewRequest(http.MethodDelete, "/issuers/"+uuid.New().String(), nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAdminHandlers_CreateSchema_ReturnsConflictOnDuplicateName(t *testing.T) {
+	svc := &fakeAdminService{createSchemaErr: repositories.ErrAdminDuplicateName}
+	mux := newTestAdminMux(NewAdminHandlers(&config.Configuration{}, svc))
+
+	req := httptest.NewRequest(http.MethodPost, "/schemas", bytes.NewBufferString(`{"url":"https://schemas.example/s.json","types":["Foo"],"context":["https://schemas.example/ctx.json"]}`))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestAdminHandlers_DeleteSchema_ReturnsNotFoundWhenMissing(t *testing.T) {
+	svc := &fakeAdminService{deleteSchemaErr: repositories.ErrAdminNotFound}
+	mux := newTestAdminMux(NewAdminHandlers(&config.Configuration{}, svc))
+
+	req := httptest.NewRequest(http.MethodDelete, "/schemas/"+uuid.New().String(), nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAdminHandlers_CreateAdminUser_ReturnsConflictOnDuplicateName(t *testing.T) {
+	svc := &fakeAdminService{createAdminUserErr: repositories.ErrAdminDuplicateName}
+	mux := newTestAdminMux(NewAdminHandlers(&config.Configuration{}, svc))
+
+	req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewBufferString(`{"name":"alice","isSuperAdmin":false}`))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestAdminHandlers_DeleteAdminUser_ReturnsNotFoundWhenMissing(t *testing.T) {
+	svc := &fakeAdminService{deactivateAdminUserErr: repositories.ErrAdminNotFound}
+	mux := newTestAdminMux(NewAdminHandlers(&config.Configuration{}, svc))
+
+	req := httptest.NewRequest(http.MethodDelete, "/users/"+uuid.New().String(), nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// NewRouter builds the HTTP mux for this node: the static docs routes and the JWT-protected
+// admin subtree. The generated StrictServerInterface routes (issuance, revocation, identities)
+// are mounted on top of this by the oapi-codegen HandlerFromMuxWithBaseURL call in main, which
+// this package does not reproduce.
+//
+// It also runs BootstrapSuperAdmin once before returning, the same way main would run it before
+// the HTTP server starts serving. If bootstrapping fails, NewRouter returns the error instead of
+// mounting /admin anyway: serving an admin subtree that a fresh table has no user able to
+// authenticate against is worse than refusing to start.
+func NewRouter(_ *config.Configuration, adminService ports.AdminService, adminHandlers *AdminHandlers) (*chi.Mux, error) {
+	if err := BootstrapSuperAdmin(context.Background(), adminService); err != nil {
+		return nil, fmt.Errorf("bootstrapping initial super admin: %w", err)
+	}
+
+	mux := chi.NewRouter()
+	RegisterStatic(mux)
+	RegisterAdmin(mux, adminHandlers)
+	return mux, nil
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 
@@ -21,6 +22,10 @@ import (
 	"github.com/polygonid/sh-id-platform/internal/repositories"
 )
 
+// defaultClaimBatchMaxSize bounds the number of credentials a single CreateClaimBatch call can
+// issue when claimBatch.maxSize is not set in the configuration.
+const defaultClaimBatchMaxSize = 500
+
 // Server implements StrictServerInterface and holds the implementation of all API controllers
 // This is the glue to the API autogenerated code
 type Server struct {
@@ -127,6 +132,56 @@ func (s *Server) CreateClaim(ctx context.Context, request CreateClaimRequestObje
 	return CreateClaim201JSONResponse{Id: resp.ID.String()}, nil
 }
 
+// CreateClaimBatch is the bulk claim creation controller. Unlike CreateClaim, it issues every
+// item in a single transaction, and reports success or failure per item instead of failing the
+// whole batch when one credential is invalid. It never touches the merkle trees itself: that
+// still only happens when a subsequent PublishState call rolls the batch's claims into a state.
+func (s *Server) CreateClaimBatch(ctx context.Context, request CreateClaimBatchRequestObject) (CreateClaimBatchResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return CreateClaimBatch400JSONResponse{N400JSONResponse{err.Error()}}, nil
+	}
+
+	if request.Body == nil {
+		return CreateClaimBatch400JSONResponse{N400JSONResponse{Message: "the request body must contain at least one claim"}}, nil
+	}
+
+	maxSize := s.cfg.ClaimBatch.MaxSize
+	if maxSize <= 0 {
+		maxSize = defaultClaimBatchMaxSize
+	}
+	if len(*request.Body) > maxSize {
+		return CreateClaimBatch400JSONResponse{N400JSONResponse{
+			Message: fmt.Sprintf("a batch can contain at most %d claims", maxSize),
+		}}, nil
+	}
+
+	reqs := make([]*ports.CreateClaimRequest, len(*request.Body))
+	for i, body := range *request.Body {
+		reqs[i] = ports.NewCreateClaimRequest(did, body.CredentialSchema, body.CredentialSubject, body.Expiration, body.Type, body.Version, body.SubjectPosition, body.MerklizedRootPosition)
+	}
+
+	results, err := s.claimService.CreateClaimBatch(ctx, did, reqs)
+	if err != nil {
+		return CreateClaimBatch500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	response := make(CreateClaimBatch201JSONResponse, len(results))
+	for i, result := range results {
+		item := CreateClaimBatchResponseItem{Index: i, Status: result.HTTPStatus}
+		if result.Err != nil {
+			msg := result.Err.Error()
+			item.Message = &msg
+		} else {
+			id := result.ID.String()
+			item.Id = &id
+		}
+		response[i] = item
+	}
+
+	return response, nil
+}
+
 // RevokeClaim is the revocation claim controller
 func (s *Server) RevokeClaim(ctx context.Context, request RevokeClaimRequestObject) (RevokeClaimResponseObject, error) {
 	if err := s.claimService.Revoke(ctx, request.Identifier, uint64(request.Nonce), ""); err != nil {
@@ -143,10 +198,12 @@ func (s *Server) RevokeClaim(ctx context.Context, request RevokeClaimRequestObje
 	}, nil
 }
 
-// GetRevocationStatus is the controller to get revocation status
+// GetRevocationStatus is the controller to get revocation status. It is a thin wrapper over
+// ports.ClaimsService.GetRevocationStatus, which dispatches to whichever
+// ports.CredentialStatusResolver this node is configured with (SparseMerkleTreeProof,
+// Iden3ReverseSparseMerkleTreeProof or Iden3OnchainSparseMerkleTreeProof).
 func (s *Server) GetRevocationStatus(ctx context.Context, request GetRevocationStatusRequestObject) (GetRevocationStatusResponseObject, error) {
 	response := GetRevocationStatus200JSONResponse{}
-	var err error
 
 	rs, err := s.claimService.GetRevocationStatus(ctx, request.Identifier, uint64(request.Nonce))
 	if err != nil {
@@ -162,8 +219,7 @@ func (s *Server) GetRevocationStatus(ctx context.Context, request GetRevocationS
 	response.Mtp.Existence = rs.MTP.Existence
 
 	if rs.MTP.NodeAux != nil {
-		key, _ := rs.MTP.NodeAux.Key.MarshalText()
-		value, _ := rs.MTP.NodeAux.Value.MarshalText()
+		key, value := ByteArray(rs.MTP.NodeAux.Key), ByteArray(rs.MTP.NodeAux.Value)
 		response.Mtp.NodeAux = &struct {
 			Key   *ByteArray `json:"key,omitempty"`
 			Value *ByteArray `json:"value,omitempty"`
@@ -172,14 +228,33 @@ func (s *Server) GetRevocationStatus(ctx context.Context, request GetRevocationS
 			Value: &value,
 		}
 	}
-	response.Mtp.Existence = rs.MTP.Existence
-	siblings := make([]ByteArray, 0)
-	for _, s := range rs.MTP.AllSiblings() {
-		sb, _ := s.MarshalText()
-		siblings = append(siblings, sb)
+
+	siblings := make([]ByteArray, len(rs.MTP.Siblings))
+	for i, sibling := range rs.MTP.Siblings {
+		siblings[i] = ByteArray(sibling)
 	}
 	response.Mtp.Siblings = &siblings
-	return response, err
+
+	return response, nil
+}
+
+// GetRevocationStatusNode is the controller used as a local fallback for the
+// Iden3ReverseSparseMerkleTreeProof credential status type: when the configured RHS node does
+// not know about a hash (e.g. it has not finished syncing yet), wallets fall back to asking the
+// issuer node directly for the node behind that hash.
+func (s *Server) GetRevocationStatusNode(ctx context.Context, request GetRevocationStatusNodeRequestObject) (GetRevocationStatusNodeResponseObject, error) {
+	node, err := s.claimService.GetRevocationStatusNode(ctx, request.Hash)
+	if err != nil {
+		if errors.Is(err, services.ErrNodeNotFound) {
+			return GetRevocationStatusNode404JSONResponse{N404JSONResponse{"node not found"}}, nil
+		}
+		return GetRevocationStatusNode500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	return GetRevocationStatusNode200JSONResponse{
+		Hash:     request.Hash,
+		Children: node.Children,
+	}, nil
 }
 
 // GetClaim is the controller to get a client.
@@ -302,12 +377,46 @@ func (s *Server) PublishIdentityState(ctx context.Context, request PublishIdenti
 		return PublishIdentityState500JSONResponse{N500JSONResponse{err.Error()}}, nil
 	}
 
+	claimIds := make([]string, len(publishedState.ClaimIDs))
+	for i, id := range publishedState.ClaimIDs {
+		claimIds[i] = id.String()
+	}
+
 	return PublishIdentityState202JSONResponse{
 		ClaimsTreeRoot:     publishedState.ClaimsTreeRoot,
 		RevocationTreeRoot: publishedState.RevocationTreeRoot,
 		RootOfRoots:        publishedState.RootOfRoots,
 		State:              publishedState.State,
 		TxID:               publishedState.TxID,
+		ClaimsIds:          claimIds,
+	}, nil
+}
+
+// GetIdentityStatePending is the controller that exposes the claims that have been issued
+// since the last published state together with the state root that would result from
+// publishing them, without actually triggering a publish.
+func (s *Server) GetIdentityStatePending(ctx context.Context, request GetIdentityStatePendingRequestObject) (GetIdentityStatePendingResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetIdentityStatePending400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	pending, err := s.identityService.GetPendingState(ctx, did)
+	if err != nil {
+		if errors.Is(err, services.ErrNoClaimsToProcess) {
+			return GetIdentityStatePending200JSONResponse{ClaimsIds: []string{}}, nil
+		}
+		return GetIdentityStatePending500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	claimIds := make([]string, len(pending.ClaimIDs))
+	for i, id := range pending.ClaimIDs {
+		claimIds[i] = id.String()
+	}
+
+	return GetIdentityStatePending200JSONResponse{
+		ClaimsIds: claimIds,
+		State:     pending.NextState,
 	}, nil
 }
 
@@ -0,0 +1,46 @@
+// Package config holds the node's runtime configuration, loaded from env vars/flags in main.
+package config
+
+// Configuration is the root configuration object, threaded into api.Server and the services it
+// depends on.
+type Configuration struct {
+	// ServerUrl is this node's own externally reachable base URL, used e.g. to build genesis DIDs.
+	ServerUrl string
+
+	CredentialStatus CredentialStatus
+	Admin            Admin
+	ClaimBatch       ClaimBatch
+}
+
+// Admin configures the JWT-authenticated /admin API.
+type Admin struct {
+	// SigningKey is the HMAC secret used to sign and verify admin JWTs.
+	SigningKey string
+}
+
+// CredentialStatus selects which ports.CredentialStatusResolver backs the credentialStatus
+// block of every credential this node issues, and the settings that resolver needs.
+type CredentialStatus struct {
+	// Type is one of SparseMerkleTreeProof, Iden3ReverseSparseMerkleTreeProof or
+	// Iden3OnchainSparseMerkleTreeProof (see ports.CredentialStatusType).
+	Type string
+	// RHSUrl is the base URL of the reverse hash service node roots are pushed to and proofs are
+	// requested from. Only used when Type is Iden3ReverseSparseMerkleTreeProof.
+	RHSUrl string
+	// OnchainContract is the address of the state contract roots are published to and read back
+	// from. Only used when Type is Iden3OnchainSparseMerkleTreeProof.
+	OnchainContract string
+	// SingleIssuer must be explicitly set to true: every claim issued by this node shares the same
+	// credentialStatus configuration, so the resolver can be selected once at startup instead of
+	// per claim, and there is no per-DID or per-claim override to fall back to. It is a *bool
+	// rather than a bool so a zero-value Configuration{} (unset) can be told apart from an explicit
+	// false - both currently refuse to start, but with a different error, since an unset field
+	// most likely means the loader never populated it rather than an operator opting out.
+	SingleIssuer *bool
+}
+
+// ClaimBatch configures the bulk claim-issuance endpoint.
+type ClaimBatch struct {
+	// MaxSize is the largest number of claims accepted in a single CreateClaimBatch request.
+	MaxSize int
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminIssuer is a DID this node is allowed to sign credentials for.
+type AdminIssuer struct {
+	ID        uuid.UUID
+	DID       string
+	Name      string
+	CreatedAt time.Time
+}
+
+// SchemaWhitelistEntry allows a schema URL, together with the credential types and JSON-LD
+// contexts declared by it, to be used when issuing credentials.
+type SchemaWhitelistEntry struct {
+	ID        uuid.UUID
+	URL       string
+	Types     []string
+	Context   []string
+	CreatedAt time.Time
+}
+
+// AdminUserStatus is the lifecycle status of an AdminUser.
+type AdminUserStatus string
+
+const (
+	// AdminUserActive admin users can authenticate and operate the admin API.
+	AdminUserActive AdminUserStatus = "active"
+	// AdminUserInactive admin users have been soft-deleted: their JWTs are rejected by
+	// adminAuthMiddleware even if not yet expired, but the row (and its audit trail) is kept.
+	AdminUserInactive AdminUserStatus = "inactive"
+)
+
+// AdminUser is an account allowed to operate the admin API.
+type AdminUser struct {
+	ID           uuid.UUID
+	Name         string
+	IsSuperAdmin bool
+	Status       AdminUserStatus
+	CreatedAt    time.Time
+}
@@ -0,0 +1,62 @@
+// Package domain holds the plain data structures shared across services, repositories and
+// gateways. It has no dependency on any other internal package.
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdentityStatus is the lifecycle status of an IdentityState.
+type IdentityStatus string
+
+const (
+	// StatusCreated is set on an IdentityState as soon as it is computed, before it is published on chain.
+	StatusCreated IdentityStatus = "created"
+	// StatusTransacted is set once the state publication transaction has been sent.
+	StatusTransacted IdentityStatus = "transacted"
+	// StatusConfirmed is set once the state publication transaction has been mined.
+	StatusConfirmed IdentityStatus = "confirmed"
+)
+
+// Identity is an iden3 identity managed by this node.
+type Identity struct {
+	Identifier string
+	State      IdentityState
+}
+
+// IdentityState is one entry in the append-only history of states a DID has gone through.
+// PreviousState is nil for the first (genesis) state.
+type IdentityState struct {
+	StateID            int64
+	Identifier         string
+	State              *string
+	RootOfRoots        *string
+	ClaimsTreeRoot     *string
+	RevocationTreeRoot *string
+	BlockTimestamp     *int
+	BlockNumber        *int
+	TxID               *string
+	PreviousState      *string
+	Status             IdentityStatus
+	ModifiedAt         time.Time
+	CreatedAt          time.Time
+}
+
+// Claim is an issued credential as tracked by the node, independent of its W3C representation.
+// IdentityState is nil until the claim has been rolled into a published identity state, at which
+// point it is set to the State hash of that IdentityState.
+type Claim struct {
+	ID            uuid.UUID
+	Identifier    string
+	Issuer        string
+	SchemaHash    string
+	SchemaURL     string
+	SchemaType    string
+	RevNonce      uint64
+	Revoked       bool
+	CoreClaimHex  string
+	IdentityState *string
+	CreatedAt     time.Time
+}
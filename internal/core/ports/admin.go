@@ -0,0 +1,65 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+const (
+	// AdminRoleSuper can manage issuers, schemas and other admin users.
+	AdminRoleSuper = "super"
+	// AdminRoleIssuerAdmin can manage issuers and schemas but not other admin users.
+	AdminRoleIssuerAdmin = "issuer-admin"
+)
+
+// AdminRepository is the persistence boundary for issuer-level policy: the issuers/DIDs this
+// node is allowed to sign for, the schema whitelist, and the admin users themselves. It is
+// backed by the same SQL layer as the other repositories in package repositories.
+type AdminRepository interface {
+	CreateIssuer(ctx context.Context, did, name string) (*domain.AdminIssuer, error)
+	GetIssuers(ctx context.Context) ([]*domain.AdminIssuer, error)
+	UpdateIssuer(ctx context.Context, id uuid.UUID, did, name string) (*domain.AdminIssuer, error)
+	DeleteIssuer(ctx context.Context, id uuid.UUID) error
+
+	CreateSchemaWhitelistEntry(ctx context.Context, url string, types, context []string) (*domain.SchemaWhitelistEntry, error)
+	GetSchemaWhitelist(ctx context.Context) ([]*domain.SchemaWhitelistEntry, error)
+	DeleteSchemaWhitelistEntry(ctx context.Context, id uuid.UUID) error
+
+	CreateAdminUser(ctx context.Context, name string, isSuperAdmin bool) (*domain.AdminUser, error)
+	GetAdminUsers(ctx context.Context) ([]*domain.AdminUser, error)
+	// GetAdminUser looks up a single admin user by id, so callers can re-check its current status.
+	GetAdminUser(ctx context.Context, id uuid.UUID) (*domain.AdminUser, error)
+	// SetAdminUserStatus transitions an admin user to status instead of deleting the row, so a
+	// deactivated user's past actions stay attributable.
+	SetAdminUserStatus(ctx context.Context, id uuid.UUID, status domain.AdminUserStatus) error
+}
+
+// AdminService is the business-logic boundary the admin HTTP handlers talk to. It owns things a
+// persistence adapter should not, such as minting the JWT handed back when an admin user is
+// created and deciding whether the admin table is empty enough to bootstrap.
+type AdminService interface {
+	CreateIssuer(ctx context.Context, did, name string) (*domain.AdminIssuer, error)
+	GetIssuers(ctx context.Context) ([]*domain.AdminIssuer, error)
+	UpdateIssuer(ctx context.Context, id uuid.UUID, did, name string) (*domain.AdminIssuer, error)
+	DeleteIssuer(ctx context.Context, id uuid.UUID) error
+
+	CreateSchemaWhitelistEntry(ctx context.Context, url string, types, context []string) (*domain.SchemaWhitelistEntry, error)
+	GetSchemaWhitelist(ctx context.Context) ([]*domain.SchemaWhitelistEntry, error)
+	DeleteSchemaWhitelistEntry(ctx context.Context, id uuid.UUID) error
+
+	// CreateAdminUser persists the new admin user and mints the JWT it will authenticate with.
+	CreateAdminUser(ctx context.Context, name string, isSuperAdmin bool) (*domain.AdminUser, string, error)
+	GetAdminUsers(ctx context.Context) ([]*domain.AdminUser, error)
+	// GetAdminUser looks up a single admin user by id. adminAuthMiddleware calls this on every
+	// request to re-check current status instead of trusting whatever was true when the JWT was
+	// minted, so a deactivated admin loses access immediately rather than at JWT expiry.
+	GetAdminUser(ctx context.Context, id uuid.UUID) (*domain.AdminUser, error)
+	DeactivateAdminUser(ctx context.Context, id uuid.UUID) error
+
+	// Bootstrap creates the first super admin and returns its JWT when the admin table is empty.
+	// It returns ("", nil) without creating anything once at least one admin user exists.
+	Bootstrap(ctx context.Context) (token string, err error)
+}
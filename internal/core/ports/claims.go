@@ -0,0 +1,126 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/iden3/iden3comm"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// CreateClaimRequest is the normalized input to ClaimsService.CreateClaim/CreateClaimBatch,
+// built from the generated API request body by NewCreateClaimRequest.
+type CreateClaimRequest struct {
+	DID                   *core.DID
+	Schema                string
+	CredentialSubject     map[string]interface{}
+	Expiration            *int64
+	Type                  string
+	Version               *uint32
+	SubjectPosition       *string
+	MerklizedRootPosition *string
+}
+
+// NewCreateClaimRequest is a CreateClaimRequest constructor.
+func NewCreateClaimRequest(did *core.DID, schema string, credentialSubject map[string]interface{}, expiration *int64, typ string, version *uint32, subjectPosition, merklizedRootPosition *string) *CreateClaimRequest {
+	return &CreateClaimRequest{
+		DID:                   did,
+		Schema:                schema,
+		CredentialSubject:     credentialSubject,
+		Expiration:            expiration,
+		Type:                  typ,
+		Version:               version,
+		SubjectPosition:       subjectPosition,
+		MerklizedRootPosition: merklizedRootPosition,
+	}
+}
+
+// CreateClaimResponse is the result of issuing a single claim.
+type CreateClaimResponse struct {
+	ID uuid.UUID
+}
+
+// ClaimsFilter narrows down ClaimsService.GetAll.
+type ClaimsFilter struct {
+	SchemaHash string
+	SchemaType string
+	Subject    string
+	QueryField string
+	Self       *bool
+	Revoked    *bool
+}
+
+// NewClaimsFilter is a ClaimsFilter constructor.
+func NewClaimsFilter(schemaHash, schemaType, subject, queryField *string, self, revoked *bool) (*ClaimsFilter, error) {
+	filter := &ClaimsFilter{Self: self, Revoked: revoked}
+	if schemaHash != nil {
+		filter.SchemaHash = *schemaHash
+	}
+	if schemaType != nil {
+		filter.SchemaType = *schemaType
+	}
+	if subject != nil {
+		filter.Subject = *subject
+	}
+	if queryField != nil {
+		filter.QueryField = *queryField
+	}
+	return filter, nil
+}
+
+// AgentRequest is the normalized iden3comm message received by the Agent endpoint.
+type AgentRequest struct {
+	ID       string
+	ThreadID string
+	Typ      iden3comm.MediaType
+	Type     iden3comm.ProtocolMessage
+	From     string
+	To       string
+	Body     interface{}
+}
+
+// NewAgentRequest returns an empty AgentRequest; the unpacked iden3comm basic message passed to
+// it is not read.
+func NewAgentRequest(_ interface{}) (*AgentRequest, error) {
+	return &AgentRequest{}, nil
+}
+
+// AgentResponse is the iden3comm message returned by the Agent endpoint.
+type AgentResponse struct {
+	ID       string
+	ThreadID string
+	Typ      iden3comm.MediaType
+	Type     iden3comm.ProtocolMessage
+	From     string
+	To       string
+	Body     interface{}
+}
+
+// BatchItemResult is the per-index outcome of ClaimsService.CreateClaimBatch.
+type BatchItemResult struct {
+	ID         uuid.UUID
+	HTTPStatus int
+	Err        error
+}
+
+// ClaimsService issues, revokes and resolves the status of claims.
+type ClaimsService interface {
+	CreateClaim(ctx context.Context, req *CreateClaimRequest) (*CreateClaimResponse, error)
+	// CreateClaimBatch persists, under a single DB transaction, every request in reqs whose schema
+	// loads, and reports the others as per-index failures. It amortizes schema loading (one fetch
+	// per unique schema URL across the batch instead of one per claim).
+	// It does not touch the merkle trees or perform a state transition; that only happens once a
+	// subsequent PublishState call rolls the batch's claims into a published state.
+	CreateClaimBatch(ctx context.Context, did *core.DID, reqs []*CreateClaimRequest) ([]BatchItemResult, error)
+	Revoke(ctx context.Context, identifier string, nonce uint64, reason string) error
+	GetRevocationStatus(ctx context.Context, identifier string, nonce uint64) (*RevocationStatus, error)
+	// GetRevocationStatusNode serves a single RHS node by hash, for the local fallback endpoint
+	// used by the Iden3ReverseSparseMerkleTreeProof credential status type.
+	GetRevocationStatusNode(ctx context.Context, hash string) (*Node, error)
+	GetByID(ctx context.Context, did *core.DID, id uuid.UUID) (*domain.Claim, error)
+	GetAll(ctx context.Context, did *core.DID, filter *ClaimsFilter) ([]*verifiable.W3CCredential, error)
+	Agent(ctx context.Context, req *AgentRequest) (*AgentResponse, error)
+}
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// ClaimsRepository is the persistence boundary for issued claims.
+type ClaimsRepository interface {
+	Save(ctx context.Context, claim *domain.Claim) (uuid.UUID, error)
+	// SaveBatch persists claims in a single DB transaction: either all of them are inserted, or
+	// (on any failure) none are. Used by ClaimsService.CreateClaimBatch so a bulk issuance never
+	// leaves a partially-written batch behind.
+	SaveBatch(ctx context.Context, claims []*domain.Claim) error
+	GetByID(ctx context.Context, did *core.DID, id uuid.UUID) (*domain.Claim, error)
+	// GetAllByState returns the union of two sets: the claims issued for did that are not yet
+	// part of any published state (IdentityState IS NULL), plus, when state is non-nil, the
+	// claims that already belong to that previously published state. The publish pipeline passes
+	// its previous state and then filters the result by IdentityState == nil itself, so the
+	// already-published claims that come back alongside the new ones are discarded there; the
+	// two branches are not mutually exclusive result sets.
+	GetAllByState(ctx context.Context, did *core.DID, state *string) ([]*domain.Claim, error)
+	// UpdateState sets the IdentityState of the given claims to newState, marking them as
+	// incorporated into that published state.
+	UpdateState(ctx context.Context, claimIDs []uuid.UUID, newState string) error
+}
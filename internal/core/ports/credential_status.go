@@ -0,0 +1,81 @@
+package ports
+
+import "context"
+
+// CredentialStatusType is the credentialStatus.type value emitted into a W3C credential, and the
+// config.Configuration.CredentialStatus.Type value that selects which CredentialStatusResolver
+// backs it.
+type CredentialStatusType string
+
+const (
+	// SparseMerkleTreeProof resolves revocation status from the issuer's own merkle trees.
+	SparseMerkleTreeProof CredentialStatusType = "SparseMerkleTreeProof"
+	// Iden3ReverseSparseMerkleTreeProof resolves revocation status from an external reverse hash
+	// service node. This node also serves the nodes it pushed through its own
+	// GetRevocationStatusNode endpoint, for wallets to fall back to.
+	Iden3ReverseSparseMerkleTreeProof CredentialStatusType = "Iden3ReverseSparseMerkleTreeProof"
+	// Iden3OnchainSparseMerkleTreeProof resolves revocation status by reading roots back from the
+	// configured state contract.
+	Iden3OnchainSparseMerkleTreeProof CredentialStatusType = "Iden3OnchainSparseMerkleTreeProof"
+)
+
+// RevocationStatus is the node/siblings proof served by GetRevocationStatus, independent of
+// which resolver produced it.
+type RevocationStatus struct {
+	Issuer struct {
+		State              *string
+		RootOfRoots        *string
+		ClaimsTreeRoot     *string
+		RevocationTreeRoot *string
+	}
+	MTP RevocationMTP
+}
+
+// RevocationMTP is the merkle-tree proof of (non-)existence of a claim's revocation nonce.
+type RevocationMTP struct {
+	Existence bool
+	Siblings  []string
+	NodeAux   *struct {
+		Key   string
+		Value string
+	}
+}
+
+// Node is a single reverse-hash-service node: a preimage the RHS protocol lets a verifier
+// recursively resolve from a tree root down to a leaf.
+type Node struct {
+	Hash     string
+	Children []string
+}
+
+// CredentialStatus is the credentialStatus block embedded into a W3C credential at issuance
+// time, built by whichever CredentialStatusResolver this node is configured with.
+type CredentialStatus struct {
+	ID              string               `json:"id"`
+	Type            CredentialStatusType `json:"type"`
+	RevocationNonce uint64               `json:"revocationNonce"`
+}
+
+// CredentialStatusResolver resolves the revocation status of a claim and, for backends that
+// require it, publishes new tree roots whenever the issuer's identity state changes.
+type CredentialStatusResolver interface {
+	// Type is the credentialStatus.type value this resolver backs.
+	Type() CredentialStatusType
+	// BuildCredentialStatus returns the credentialStatus block to embed into a credential issued
+	// by issuerDID with the given revocation nonce, pointing at whichever backend this resolver
+	// serves proofs from.
+	BuildCredentialStatus(issuerDID string, revNonce uint64) CredentialStatus
+	// RevocationStatus returns the proof that revNonce has (or has not) been revoked for did.
+	RevocationStatus(ctx context.Context, did string, revNonce uint64) (*RevocationStatus, error)
+	// PushRoots is called after every successful state transition so that external backends
+	// (RHS node, state contract) learn about the new roots. It is a no-op for resolvers that
+	// read roots directly off the issuer's own trees.
+	PushRoots(ctx context.Context, claimsTreeRoot, revocationTreeRoot, rootOfRoots string) error
+}
+
+// RHSNodeRepository stores the RHS nodes this issuer has produced, so GetRevocationStatusNode can
+// serve them locally when the configured external RHS node does not have them (yet).
+type RHSNodeRepository interface {
+	Save(ctx context.Context, node *Node) error
+	GetByHash(ctx context.Context, hash string) (*Node, error)
+}
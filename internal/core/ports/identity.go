@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// PendingState describes the claims that have been issued since the last published state for a
+// DID, together with the state root that would result from publishing them.
+type PendingState struct {
+	ClaimIDs  []uuid.UUID
+	NextState string
+}
+
+// IdentityService manages the lifecycle of identities: creation, listing and the claims/state
+// bookkeeping that is not yet published on chain.
+type IdentityService interface {
+	Create(ctx context.Context, method, blockchain, network string, serverURL string) (*domain.Identity, error)
+	Get(ctx context.Context) ([]string, error)
+	// GetPendingState returns the claims issued since the last published IdentityState for did,
+	// and the state root that publishing them would produce, without publishing anything.
+	// It returns ErrNoClaimsToProcess when every issued claim is already part of the last
+	// published state.
+	GetPendingState(ctx context.Context, did *core.DID) (*PendingState, error)
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// IdentityStateRepository is the persistence boundary for the history of identity states.
+type IdentityStateRepository interface {
+	Save(ctx context.Context, state *domain.IdentityState) error
+	// GetLatestStateByIdentifier returns the most recently published IdentityState for did, or
+	// nil if the DID has never been published.
+	GetLatestStateByIdentifier(ctx context.Context, did *core.DID) (*domain.IdentityState, error)
+}
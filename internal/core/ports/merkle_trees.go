@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// MerkleTreeService wraps the identity's claims/revocation/roots merkle trees (iTrees in the
+// publish pipeline). It is the only thing in this codebase allowed to mutate those trees.
+type MerkleTreeService interface {
+	// AddClaim incorporates claim into the claims tree. It must only be called for claims whose
+	// domain.Claim.IdentityState is still nil, i.e. not yet part of a published state.
+	AddClaim(ctx context.Context, claim *domain.Claim) error
+	// CurrentRoots returns the current claims tree root, revocation tree root and root of roots
+	// without requiring a state transition to have happened.
+	CurrentRoots(ctx context.Context) (claimsTreeRoot, revocationTreeRoot, rootOfRoots string, err error)
+	// PreviewState computes the state root that would result from adding claims to the trees,
+	// without mutating them or requiring a state transition. Used to answer the pending-state
+	// endpoint without side effects.
+	PreviewState(ctx context.Context, claims []*domain.Claim) (nextState string, err error)
+	// GetClaimProof returns the merkle-tree proof of (non-)existence of revNonce in the revocation
+	// tree, for CredentialStatusResolver.RevocationStatus to embed alongside the tree roots. This
+	// node's own trees are the only source of proofs even for resolvers that read their roots from
+	// elsewhere (e.g. Iden3OnchainSparseMerkleTreeProof), since nothing but this node's iTrees ever
+	// builds one.
+	GetClaimProof(ctx context.Context, revNonce uint64) (*RevocationMTP, error)
+}
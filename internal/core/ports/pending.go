@@ -0,0 +1,42 @@
+package ports
+
+import (
+	"context"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// PendingClaims loads the claims issued for did since its last published IdentityState: it looks
+// up the previous state (if any), fetches every claim scoped to it via ClaimsRepository.GetAllByState,
+// and keeps only the ones not yet rolled into any published state (domain.Claim.IdentityState ==
+// nil). It also returns the previous state's hash, nil for a DID that has never been published.
+//
+// This is shared by gateways.publisherGateway.PublishState and
+// services.identityService.GetPendingState so "what's new since the last publish" is resolved
+// identically by both: the pending-state preview and the actual publish must never disagree about
+// which claims a state transition would incorporate.
+func PendingClaims(ctx context.Context, identityStateRepo IdentityStateRepository, claimsRepo ClaimsRepository, did *core.DID) (pending []*domain.Claim, previousStateHash *string, err error) {
+	lastState, err := identityStateRepo.GetLatestStateByIdentifier(ctx, did)
+	if err != nil {
+		return nil, nil, err
+	}
+	if lastState != nil {
+		previousStateHash = lastState.State
+	}
+
+	claims, err := claimsRepo.GetAllByState(ctx, did, previousStateHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pending = make([]*domain.Claim, 0, len(claims))
+	for _, claim := range claims {
+		if claim.IdentityState == nil {
+			pending = append(pending, claim)
+		}
+	}
+
+	return pending, previousStateHash, nil
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// PublishedState is the result of successfully publishing a new identity state.
+type PublishedState struct {
+	ClaimsTreeRoot     *string
+	RevocationTreeRoot *string
+	RootOfRoots        *string
+	State              *string
+	TxID               *string
+	// ClaimIDs are the claims that were rolled into this published state.
+	ClaimIDs []uuid.UUID
+}
+
+// Publisher drives the publication of a DID's identity state.
+type Publisher interface {
+	// PublishState scopes the claims issued since the last published state for did, adds them to
+	// the identity's merkle trees and saves the resulting state as the DID's latest one.
+	PublishState(ctx context.Context, did *core.DID) (*PublishedState, error)
+}
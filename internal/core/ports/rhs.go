@@ -0,0 +1,17 @@
+package ports
+
+import "context"
+
+// RHSPublisher talks to an external reverse hash service node: it pushes new tree roots after a
+// state transition and queries proofs back from it for GetRevocationStatus.
+type RHSPublisher interface {
+	PushState(ctx context.Context, claimsTreeRoot, revocationTreeRoot, rootOfRoots string) error
+	GetRevocationStatus(ctx context.Context, did string, revNonce uint64) (*RevocationStatus, error)
+}
+
+// OnchainRootsReader talks to the configured state contract: it publishes the revocation root
+// after a state transition and reads roots back from chain for GetRevocationStatus.
+type OnchainRootsReader interface {
+	GetRoots(ctx context.Context, contract, did string) (claimsTreeRoot, revocationTreeRoot, rootOfRoots string, err error)
+	PublishRoots(ctx context.Context, contract, claimsTreeRoot, revocationTreeRoot, rootOfRoots string) error
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/iden3/go-schema-processor/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+// Schema is a loaded and parsed JSON-LD credential schema, keyed by the URL it was fetched from.
+type Schema struct {
+	URL     string
+	Types   []string
+	Context []string
+}
+
+// SchemaService loads JSON-LD schemas and converts between the node's internal claim model and
+// W3C verifiable credentials.
+type SchemaService interface {
+	// LoadSchema fetches and parses the schema at url. Implementations are expected to cache by
+	// url, since the same schema is typically reused across many claims.
+	LoadSchema(ctx context.Context, url string) (*Schema, error)
+	FromClaimModelToW3CCredential(claim domain.Claim) (*verifiable.W3CCredential, error)
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// adminJWTTTL is how long a minted admin JWT is valid for before it must be reissued.
+const adminJWTTTL = 24 * time.Hour
+
+// adminService implements ports.AdminService on top of ports.AdminRepository. It is the only
+// place in this codebase that knows how to mint an admin JWT; AdminRepository only persists rows.
+type adminService struct {
+	repo       ports.AdminRepository
+	signingKey string
+}
+
+// NewAdminService is an adminService constructor.
+func NewAdminService(repo ports.AdminRepository, signingKey string) ports.AdminService {
+	return &adminService{repo: repo, signingKey: signingKey}
+}
+
+func (s *adminService) CreateIssuer(ctx context.Context, did, name string) (*domain.AdminIssuer, error) {
+	return s.repo.CreateIssuer(ctx, did, name)
+}
+
+func (s *adminService) GetIssuers(ctx context.Context) ([]*domain.AdminIssuer, error) {
+	return s.repo.GetIssuers(ctx)
+}
+
+func (s *adminService) UpdateIssuer(ctx context.Context, id uuid.UUID, did, name string) (*domain.AdminIssuer, error) {
+	return s.repo.UpdateIssuer(ctx, id, did, name)
+}
+
+func (s *adminService) DeleteIssuer(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteIssuer(ctx, id)
+}
+
+func (s *adminService) CreateSchemaWhitelistEntry(ctx context.Context, url string, types, ldContext []string) (*domain.SchemaWhitelistEntry, error) {
+	return s.repo.CreateSchemaWhitelistEntry(ctx, url, types, ldContext)
+}
+
+func (s *adminService) GetSchemaWhitelist(ctx context.Context) ([]*domain.SchemaWhitelistEntry, error) {
+	return s.repo.GetSchemaWhitelist(ctx)
+}
+
+func (s *adminService) DeleteSchemaWhitelistEntry(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteSchemaWhitelistEntry(ctx, id)
+}
+
+// CreateAdminUser persists the user and mints the JWT it will authenticate with. Minting the
+// token is business logic (it decides the role claim and expiry), so it lives here rather than
+// in AdminRepository, which only knows how to read and write rows.
+func (s *adminService) CreateAdminUser(ctx context.Context, name string, isSuperAdmin bool) (*domain.AdminUser, string, error) {
+	user, err := s.repo.CreateAdminUser(ctx, name, isSuperAdmin)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := s.mintToken(user)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return user, token, nil
+}
+
+func (s *adminService) GetAdminUsers(ctx context.Context) ([]*domain.AdminUser, error) {
+	return s.repo.GetAdminUsers(ctx)
+}
+
+func (s *adminService) GetAdminUser(ctx context.Context, id uuid.UUID) (*domain.AdminUser, error) {
+	return s.repo.GetAdminUser(ctx, id)
+}
+
+func (s *adminService) DeactivateAdminUser(ctx context.Context, id uuid.UUID) error {
+	return s.repo.SetAdminUserStatus(ctx, id, domain.AdminUserInactive)
+}
+
+// Bootstrap creates the first super admin and returns its JWT, stepped-ca style, printed once by
+// the caller. It is a no-op once the admin table already has at least one user.
+func (s *adminService) Bootstrap(ctx context.Context) (string, error) {
+	users, err := s.repo.GetAdminUsers(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(users) > 0 {
+		return "", nil
+	}
+
+	_, token, err := s.CreateAdminUser(ctx, "bootstrap", true)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *adminService) mintToken(user *domain.AdminUser) (string, error) {
+	role := ports.AdminRoleIssuerAdmin
+	if user.IsSuperAdmin {
+		role = ports.AdminRoleSuper
+	}
+
+	claims := jwt.MapClaims{
+		"sub":  user.ID.String(),
+		"role": role,
+		"exp":  time.Now().Add(adminJWTTTL).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.signingKey))
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// claimsService implements ports.ClaimsService.
+type claimsService struct {
+	claimsRepo    ports.ClaimsRepository
+	mts           ports.MerkleTreeService
+	resolver      ports.CredentialStatusResolver
+	schemaService ports.SchemaService
+}
+
+// NewClaimsService is a claimsService constructor. resolver is the single
+// ports.CredentialStatusResolver this node issues credentialStatus blocks against (see
+// CredentialStatus.SingleIssuer in config.Configuration).
+func NewClaimsService(claimsRepo ports.ClaimsRepository, mts ports.MerkleTreeService, resolver ports.CredentialStatusResolver, schemaService ports.SchemaService) ports.ClaimsService {
+	return &claimsService{claimsRepo: claimsRepo, mts: mts, resolver: resolver, schemaService: schemaService}
+}
+
+// CreateClaim issues a single credential: it loads req.Schema and persists the resulting claim.
+// It never touches the merkle trees itself — a claim is only ever added to the tree once, by the
+// publish pipeline (gateways.publisherGateway.PublishState), which scopes itself to claims whose
+// IdentityState is still nil. Adding it here too would mean the next publish adds it a second
+// time and a real sparse merkle tree rejects a duplicate-index insert. Bulk issuance is
+// CreateClaimBatch.
+func (s *claimsService) CreateClaim(ctx context.Context, req *ports.CreateClaimRequest) (*ports.CreateClaimResponse, error) {
+	schema, err := s.schemaService.LoadSchema(ctx, req.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	claim := newClaimFromRequest(req.DID, req, schema)
+
+	if _, err := s.claimsRepo.Save(ctx, claim); err != nil {
+		return nil, err
+	}
+
+	return &ports.CreateClaimResponse{ID: claim.ID}, nil
+}
+
+// CreateClaimBatch issues every request in reqs under a single DB transaction. Schema loading is
+// amortized: each unique CredentialSchema URL in the batch is only ever loaded once, no matter
+// how many requests reference it. A request whose schema fails to load is reported as a per-index
+// failure instead of aborting the rest of the batch; only claims whose schema loaded successfully
+// are persisted, in one SaveBatch call rather than one Save per claim. Like CreateClaim, it never
+// calls AddClaim itself: the publish pipeline is the only caller that adds a claim to the merkle
+// trees, exactly once, when it is rolled into a published state.
+func (s *claimsService) CreateClaimBatch(ctx context.Context, did *core.DID, reqs []*ports.CreateClaimRequest) ([]ports.BatchItemResult, error) {
+	results := make([]ports.BatchItemResult, len(reqs))
+
+	schemas := make(map[string]*ports.Schema, len(reqs))
+	toSave := make([]*domain.Claim, 0, len(reqs))
+	toSaveIndex := make([]int, 0, len(reqs))
+
+	for i, req := range reqs {
+		schema, ok := schemas[req.Schema]
+		if !ok {
+			loaded, err := s.schemaService.LoadSchema(ctx, req.Schema)
+			if err != nil {
+				results[i] = ports.BatchItemResult{HTTPStatus: http.StatusUnprocessableEntity, Err: err}
+				continue
+			}
+			schema = loaded
+			schemas[req.Schema] = schema
+		}
+
+		claim := newClaimFromRequest(did, req, schema)
+
+		toSave = append(toSave, claim)
+		toSaveIndex = append(toSaveIndex, i)
+	}
+
+	if len(toSave) == 0 {
+		return results, nil
+	}
+
+	if err := s.claimsRepo.SaveBatch(ctx, toSave); err != nil {
+		for _, idx := range toSaveIndex {
+			results[idx] = ports.BatchItemResult{HTTPStatus: http.StatusInternalServerError, Err: err}
+		}
+		return results, nil
+	}
+
+	for i, claim := range toSave {
+		results[toSaveIndex[i]] = ports.BatchItemResult{ID: claim.ID, HTTPStatus: http.StatusCreated}
+	}
+
+	return results, nil
+}
+
+// newClaimFromRequest builds the domain.Claim persisted for a single CreateClaim/CreateClaimBatch
+// item. RevNonce identifies the claim's slot in the revocation tree; it is randomly generated the
+// same way the rest of this codebase's claim issuance does, since nothing about req determines it.
+func newClaimFromRequest(did *core.DID, req *ports.CreateClaimRequest, schema *ports.Schema) *domain.Claim {
+	return &domain.Claim{
+		ID:         uuid.New(),
+		Identifier: did.String(),
+		Issuer:     did.String(),
+		SchemaURL:  schema.URL,
+		SchemaType: req.Type,
+		RevNonce:   rand.Uint64(), //nolint:gosec // revocation nonce collisions are handled at the unique index, not security-sensitive here
+	}
+}
+
+func (s *claimsService) Revoke(_ context.Context, _ string, _ uint64, _ string) error {
+	return errors.New("not implemented")
+}
+
+// GetRevocationStatus dispatches to whichever ports.CredentialStatusResolver this node was
+// configured with (SparseMerkleTreeProof, Iden3ReverseSparseMerkleTreeProof or
+// Iden3OnchainSparseMerkleTreeProof), so a wallet following the credential's
+// credentialStatus.type always gets a valid proof from the backend the issuer actually chose.
+func (s *claimsService) GetRevocationStatus(ctx context.Context, identifier string, nonce uint64) (*ports.RevocationStatus, error) {
+	return s.resolver.RevocationStatus(ctx, identifier, nonce)
+}
+
+// GetRevocationStatusNode serves the local fallback used by the
+// Iden3ReverseSparseMerkleTreeProof resolver. It returns ErrNodeNotFound for any other resolver,
+// since only that backend persists nodes locally.
+func (s *claimsService) GetRevocationStatusNode(ctx context.Context, hash string) (*ports.Node, error) {
+	nodeServer, ok := s.resolver.(interface {
+		GetNode(ctx context.Context, hash string) (*ports.Node, error)
+	})
+	if !ok {
+		return nil, ErrNodeNotFound
+	}
+	return nodeServer.GetNode(ctx, hash)
+}
+
+func (s *claimsService) GetByID(_ context.Context, _ *core.DID, _ uuid.UUID) (*domain.Claim, error) {
+	return nil, ErrClaimNotFound
+}
+
+func (s *claimsService) GetAll(_ context.Context, _ *core.DID, _ *ports.ClaimsFilter) ([]*verifiable.W3CCredential, error) {
+	return nil, nil
+}
+
+func (s *claimsService) Agent(_ context.Context, _ *ports.AgentRequest) (*ports.AgentResponse, error) {
+	return nil, errors.New("not implemented")
+}
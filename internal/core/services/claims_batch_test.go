@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+	"github.com/polygonid/sh-id-platform/internal/gateways"
+)
+
+// fakeBatchClaimsRepo is an in-memory ports.ClaimsRepository used only by this test. It counts
+// SaveBatch calls so the test can assert the whole batch is persisted under a single transaction.
+type fakeBatchClaimsRepo struct {
+	mu             sync.Mutex
+	claims         []*domain.Claim
+	saveBatchCalls int
+}
+
+func (f *fakeBatchClaimsRepo) Save(_ context.Context, claim *domain.Claim) (uuid.UUID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if claim.ID == uuid.Nil {
+		claim.ID = uuid.New()
+	}
+	f.claims = append(f.claims, claim)
+	return claim.ID, nil
+}
+
+func (f *fakeBatchClaimsRepo) SaveBatch(_ context.Context, claims []*domain.Claim) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.saveBatchCalls++
+	f.claims = append(f.claims, claims...)
+	return nil
+}
+
+func (f *fakeBatchClaimsRepo) GetByID(_ context.Context, _ *core.DID, _ uuid.UUID) (*domain.Claim, error) {
+	return nil, nil
+}
+
+// GetAllByState returns the claims not yet part of any published state, the same scoping
+// gateways.publisherGateway.PublishState relies on, so this fake can also drive a real Publisher
+// in tests that need to exercise CreateClaimBatch followed by a state transition.
+func (f *fakeBatchClaimsRepo) GetAllByState(_ context.Context, _ *core.DID, state *string) ([]*domain.Claim, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var result []*domain.Claim
+	for _, claim := range f.claims {
+		if claim.IdentityState == nil {
+			result = append(result, claim)
+			continue
+		}
+		if state != nil && *claim.IdentityState == *state {
+			result = append(result, claim)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeBatchClaimsRepo) UpdateState(_ context.Context, claimIDs []uuid.UUID, newState string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	updated := make(map[uuid.UUID]bool, len(claimIDs))
+	for _, id := range claimIDs {
+		updated[id] = true
+	}
+	for _, claim := range f.claims {
+		if updated[claim.ID] {
+			state := newState
+			claim.IdentityState = &state
+		}
+	}
+	return nil
+}
+
+// fakeBatchMerkleTrees is an in-memory ports.MerkleTreeService that counts AddClaim calls, so the
+// test can assert CreateClaimBatch never calls it directly: AddClaim is the publish pipeline's
+// job alone, gated on IdentityState == nil, and CreateClaimBatch never sets that field.
+type fakeBatchMerkleTrees struct {
+	mu            sync.Mutex
+	addClaimCalls int
+}
+
+func (f *fakeBatchMerkleTrees) AddClaim(_ context.Context, _ *domain.Claim) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.addClaimCalls++
+	return nil
+}
+
+// CurrentRoots derives the claims tree root from the number of claims added so far, so tests can
+// tell states with a different number of incorporated claims apart.
+func (f *fakeBatchMerkleTrees) CurrentRoots(_ context.Context) (string, string, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fmt.Sprintf("claims-root(%d)", f.addClaimCalls), "revocation-root", "roots-of-roots", nil
+}
+
+func (f *fakeBatchMerkleTrees) PreviewState(_ context.Context, _ []*domain.Claim) (string, error) {
+	return "next-state", nil
+}
+
+func (f *fakeBatchMerkleTrees) GetClaimProof(_ context.Context, _ uint64) (*ports.RevocationMTP, error) {
+	return &ports.RevocationMTP{Existence: false}, nil
+}
+
+// fakeSchemaService is an in-memory ports.SchemaService that counts, per URL, how many times
+// LoadSchema was actually invoked — unlike the real schemaService it does not cache, so it
+// reveals whether the caller (CreateClaimBatch) is the one doing the deduplication.
+type fakeSchemaService struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func newFakeSchemaService() *fakeSchemaService {
+	return &fakeSchemaService{calls: make(map[string]int)}
+}
+
+func (f *fakeSchemaService) LoadSchema(_ context.Context, url string) (*ports.Schema, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[url]++
+	return &ports.Schema{URL: url}, nil
+}
+
+func (f *fakeSchemaService) FromClaimModelToW3CCredential(_ domain.Claim) (*verifiable.W3CCredential, error) {
+	return nil, nil
+}
+
+func TestClaimsService_CreateClaimBatch_AmortizesSchemaLoadingAndSavesUnderOneTransaction(t *testing.T) {
+	ctx := context.Background()
+	did, err := core.ParseDID("did:iden3:polygon:mumbai:wztRj5oz3J5SFD8VnqMS43yVu4nWyndbQgekyKVs4")
+	require.NoError(t, err)
+
+	const (
+		totalClaims   = 1000
+		uniqueSchemas = 5
+	)
+
+	claimsRepo := &fakeBatchClaimsRepo{}
+	trees := &fakeBatchMerkleTrees{}
+	schemaService := newFakeSchemaService()
+	svc := NewClaimsService(claimsRepo, trees, nil, schemaService)
+
+	reqs := make([]*ports.CreateClaimRequest, totalClaims)
+	for i := range reqs {
+		schemaURL := fmt.Sprintf("https://schemas.example/schema-%d.json", i%uniqueSchemas)
+		reqs[i] = ports.NewCreateClaimRequest(did, schemaURL, map[string]interface{}{"id": i}, nil, "TestCredential", nil, nil, nil)
+	}
+
+	results, err := svc.CreateClaimBatch(ctx, did, reqs)
+	require.NoError(t, err)
+	require.Len(t, results, totalClaims)
+
+	for _, result := range results {
+		require.Equal(t, http.StatusCreated, result.HTTPStatus)
+		require.NotEqual(t, uuid.Nil, result.ID)
+	}
+
+	// Amortized schema loading: one LoadSchema call per unique URL, not one per claim. A
+	// per-claim (sequential) approach would have made totalClaims calls instead.
+	require.Len(t, schemaService.calls, uniqueSchemas)
+	for url, calls := range schemaService.calls {
+		require.Equalf(t, 1, calls, "schema %s should only be loaded once across the batch", url)
+	}
+
+	// Single DB transaction for the whole batch, not one per claim.
+	require.Equal(t, 1, claimsRepo.saveBatchCalls)
+
+	// CreateClaimBatch only persists; the merkle trees are only ever touched by the publish
+	// pipeline, once a claim is actually rolled into a published state.
+	require.Equal(t, 0, trees.addClaimCalls)
+}
+
+// fakeBatchIdentityStateRepo is an in-memory ports.IdentityStateRepository used only by this test.
+type fakeBatchIdentityStateRepo struct {
+	mu     sync.Mutex
+	states []*domain.IdentityState
+}
+
+func (f *fakeBatchIdentityStateRepo) Save(_ context.Context, state *domain.IdentityState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.states = append(f.states, state)
+	return nil
+}
+
+func (f *fakeBatchIdentityStateRepo) GetLatestStateByIdentifier(_ context.Context, _ *core.DID) (*domain.IdentityState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.states) == 0 {
+		return nil, nil
+	}
+	return f.states[len(f.states)-1], nil
+}
+
+// TestClaimsService_CreateClaimBatch_ThenPublish_MatchesSequentialCreateClaim issues the same set
+// of claims two ways - once as a single CreateClaimBatch followed by one PublishState, once as one
+// CreateClaim+PublishState pair per claim - and asserts both converge on the same final claims
+// tree root. CreateClaimBatch itself no longer touches the merkle trees (see
+// ports.ClaimsService.CreateClaimBatch), so the only way to protect the original "batch and
+// sequential issuance must agree" invariant is by exercising the full issue-then-publish pipeline.
+func TestClaimsService_CreateClaimBatch_ThenPublish_MatchesSequentialCreateClaim(t *testing.T) {
+	ctx := context.Background()
+	did, err := core.ParseDID("did:iden3:polygon:mumbai:wztRj5oz3J5SFD8VnqMS43yVu4nWyndbQgekyKVs4")
+	require.NoError(t, err)
+
+	const totalClaims = 50
+
+	newReqs := func() []*ports.CreateClaimRequest {
+		reqs := make([]*ports.CreateClaimRequest, totalClaims)
+		for i := range reqs {
+			reqs[i] = ports.NewCreateClaimRequest(did, "https://schemas.example/schema.json", map[string]interface{}{"id": i}, nil, "TestCredential", nil, nil, nil)
+		}
+		return reqs
+	}
+
+	// Batch path: one CreateClaimBatch call, then one PublishState call.
+	batchClaimsRepo := &fakeBatchClaimsRepo{}
+	batchTrees := &fakeBatchMerkleTrees{}
+	batchSvc := NewClaimsService(batchClaimsRepo, batchTrees, nil, newFakeSchemaService())
+	batchPublisher := gateways.NewPublisher(&fakeBatchIdentityStateRepo{}, batchClaimsRepo, batchTrees, nil)
+
+	_, err = batchSvc.CreateClaimBatch(ctx, did, newReqs())
+	require.NoError(t, err)
+	batchPublished, err := batchPublisher.PublishState(ctx, did)
+	require.NoError(t, err)
+
+	// Sequential path: one CreateClaim+PublishState pair per claim.
+	seqClaimsRepo := &fakeBatchClaimsRepo{}
+	seqTrees := &fakeBatchMerkleTrees{}
+	seqSvc := NewClaimsService(seqClaimsRepo, seqTrees, nil, newFakeSchemaService())
+	seqPublisher := gateways.NewPublisher(&fakeBatchIdentityStateRepo{}, seqClaimsRepo, seqTrees, nil)
+
+	var seqPublished *ports.PublishedState
+	for _, req := range newReqs() {
+		_, err := seqSvc.CreateClaim(ctx, req)
+		require.NoError(t, err)
+		seqPublished, err = seqPublisher.PublishState(ctx, did)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, *seqPublished.State, *batchPublished.State, "batch issuance must converge to the same final state root as issuing sequentially")
+}
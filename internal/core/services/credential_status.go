@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// NewCredentialStatusResolver selects the ports.CredentialStatusResolver backing
+// cfg.CredentialStatus.Type. Called once at startup since CredentialStatus.SingleIssuer means
+// every claim this node issues shares the same backend: this codebase only ever selects one
+// resolver at startup and has no per-DID or per-claim override, so it refuses to start unless
+// SingleIssuer is explicitly true instead of silently behaving as if it were. serverURL is used
+// to build this node's own credentialStatus.id when no external backend is configured.
+func NewCredentialStatusResolver(cfg *config.Configuration, serverURL string, claimsRepo ports.ClaimsRepository, mts ports.MerkleTreeService, rhsClient ports.RHSPublisher, rhsNodes ports.RHSNodeRepository, onchain ports.OnchainRootsReader) (ports.CredentialStatusResolver, error) {
+	switch {
+	case cfg.CredentialStatus.SingleIssuer == nil:
+		return nil, fmt.Errorf("credentialStatus.singleIssuer is not configured: this node only selects one CredentialStatusResolver at startup, shared by every DID it issues for, so it must be set to true explicitly")
+	case !*cfg.CredentialStatus.SingleIssuer:
+		return nil, fmt.Errorf("credentialStatus.singleIssuer=false is not supported: this node only selects one CredentialStatusResolver at startup, shared by every DID it issues for")
+	}
+
+	switch ports.CredentialStatusType(cfg.CredentialStatus.Type) {
+	case ports.Iden3ReverseSparseMerkleTreeProof:
+		return &rhsResolver{client: rhsClient, nodes: rhsNodes, rhsURL: cfg.CredentialStatus.RHSUrl}, nil
+	case ports.Iden3OnchainSparseMerkleTreeProof:
+		return &onchainResolver{reader: onchain, contract: cfg.CredentialStatus.OnchainContract, mts: mts}, nil
+	case ports.SparseMerkleTreeProof, "":
+		return &smtResolver{claimsRepo: claimsRepo, mts: mts, serverURL: serverURL}, nil
+	default:
+		return nil, fmt.Errorf("unsupported credentialStatus.type %q", cfg.CredentialStatus.Type)
+	}
+}
+
+// smtResolver is the original behavior: it resolves revocation status straight from this node's
+// own claims/revocation trees.
+type smtResolver struct {
+	claimsRepo ports.ClaimsRepository
+	mts        ports.MerkleTreeService
+	serverURL  string
+}
+
+func (r *smtResolver) Type() ports.CredentialStatusType { return ports.SparseMerkleTreeProof }
+
+func (r *smtResolver) BuildCredentialStatus(issuerDID string, revNonce uint64) ports.CredentialStatus {
+	return ports.CredentialStatus{
+		ID:              fmt.Sprintf("%s/v1/%s/claims/revocation/status/%d", r.serverURL, issuerDID, revNonce),
+		Type:            ports.SparseMerkleTreeProof,
+		RevocationNonce: revNonce,
+	}
+}
+
+func (r *smtResolver) RevocationStatus(ctx context.Context, _ string, revNonce uint64) (*ports.RevocationStatus, error) {
+	claimsTreeRoot, revocationTreeRoot, rootOfRoots, err := r.mts.CurrentRoots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mtp, err := r.mts.GetClaimProof(ctx, revNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ports.RevocationStatus{MTP: *mtp}
+	status.Issuer.ClaimsTreeRoot = &claimsTreeRoot
+	status.Issuer.RevocationTreeRoot = &revocationTreeRoot
+	status.Issuer.RootOfRoots = &rootOfRoots
+	return status, nil
+}
+
+// PushRoots is a no-op: wallets read the proof straight from this node, there is nothing external to push to.
+func (r *smtResolver) PushRoots(_ context.Context, _, _, _ string) error { return nil }
+
+// rhsResolver pushes this node's tree roots to an external reverse hash service, and answers
+// proof requests by querying that same RHS node. It also keeps a local copy of every node it
+// pushes, which GetNode serves for the GetRevocationStatusNode fallback endpoint; RevocationStatus
+// itself only asks the RHS node and returns its error when that call fails.
+type rhsResolver struct {
+	client ports.RHSPublisher
+	nodes  ports.RHSNodeRepository
+	rhsURL string
+}
+
+func (r *rhsResolver) Type() ports.CredentialStatusType {
+	return ports.Iden3ReverseSparseMerkleTreeProof
+}
+
+func (r *rhsResolver) BuildCredentialStatus(_ string, revNonce uint64) ports.CredentialStatus {
+	return ports.CredentialStatus{
+		ID:              r.rhsURL,
+		Type:            ports.Iden3ReverseSparseMerkleTreeProof,
+		RevocationNonce: revNonce,
+	}
+}
+
+func (r *rhsResolver) RevocationStatus(ctx context.Context, did string, revNonce uint64) (*ports.RevocationStatus, error) {
+	return r.client.GetRevocationStatus(ctx, did, revNonce)
+}
+
+func (r *rhsResolver) PushRoots(ctx context.Context, claimsTreeRoot, revocationTreeRoot, rootOfRoots string) error {
+	node := &ports.Node{Hash: rootOfRoots, Children: []string{claimsTreeRoot, revocationTreeRoot}}
+	if err := r.nodes.Save(ctx, node); err != nil {
+		return err
+	}
+	return r.client.PushState(ctx, claimsTreeRoot, revocationTreeRoot, rootOfRoots)
+}
+
+// GetNode serves a previously pushed RHS node, for the local /node/{hash} fallback endpoint.
+func (r *rhsResolver) GetNode(ctx context.Context, hash string) (*ports.Node, error) {
+	node, err := r.nodes.GetByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, ErrNodeNotFound
+	}
+	return node, nil
+}
+
+// onchainResolver publishes the revocation tree root to the configured state contract and
+// answers status queries by reading roots back from chain instead of from local storage. The
+// proof itself still comes from this node's own trees (mts): the chain only ever stores roots,
+// never the sibling nodes a wallet needs to verify a claim against them.
+type onchainResolver struct {
+	reader   ports.OnchainRootsReader
+	contract string
+	mts      ports.MerkleTreeService
+}
+
+func (r *onchainResolver) Type() ports.CredentialStatusType {
+	return ports.Iden3OnchainSparseMerkleTreeProof
+}
+
+func (r *onchainResolver) BuildCredentialStatus(_ string, revNonce uint64) ports.CredentialStatus {
+	return ports.CredentialStatus{
+		ID:              fmt.Sprintf("%s/getRevocationStatus", r.contract),
+		Type:            ports.Iden3OnchainSparseMerkleTreeProof,
+		RevocationNonce: revNonce,
+	}
+}
+
+func (r *onchainResolver) RevocationStatus(ctx context.Context, did string, revNonce uint64) (*ports.RevocationStatus, error) {
+	claimsTreeRoot, revocationTreeRoot, rootOfRoots, err := r.reader.GetRoots(ctx, r.contract, did)
+	if err != nil {
+		return nil, err
+	}
+
+	mtp, err := r.mts.GetClaimProof(ctx, revNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ports.RevocationStatus{MTP: *mtp}
+	status.Issuer.ClaimsTreeRoot = &claimsTreeRoot
+	status.Issuer.RevocationTreeRoot = &revocationTreeRoot
+	status.Issuer.RootOfRoots = &rootOfRoots
+	return status, nil
+}
+
+func (r *onchainResolver) PushRoots(ctx context.Context, claimsTreeRoot, revocationTreeRoot, rootOfRoots string) error {
+	return r.reader.PublishRoots(ctx, r.contract, claimsTreeRoot, revocationTreeRoot, rootOfRoots)
+}
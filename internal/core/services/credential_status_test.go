@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/config"
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// boolPtr is a convenience for setting config.CredentialStatus.SingleIssuer, which is a *bool so
+// a zero-value Configuration{} can be told apart from an explicit false.
+func boolPtr(b bool) *bool { return &b }
+
+// fakeMerkleTrees is an in-memory ports.MerkleTreeService used only by this test, just enough to
+// show that a resolver's RevocationStatus actually asks it for a proof instead of leaving MTP at
+// its zero value.
+type fakeMerkleTrees struct {
+	claimsTreeRoot, revocationTreeRoot, rootOfRoots string
+	proof                                           ports.RevocationMTP
+}
+
+func (f *fakeMerkleTrees) AddClaim(context.Context, *domain.Claim) error { return nil }
+
+func (f *fakeMerkleTrees) CurrentRoots(context.Context) (string, string, string, error) {
+	return f.claimsTreeRoot, f.revocationTreeRoot, f.rootOfRoots, nil
+}
+
+func (f *fakeMerkleTrees) PreviewState(context.Context, []*domain.Claim) (string, error) {
+	return "", nil
+}
+
+func (f *fakeMerkleTrees) GetClaimProof(_ context.Context, _ uint64) (*ports.RevocationMTP, error) {
+	return &f.proof, nil
+}
+
+// fakeOnchainRootsReader is an in-memory ports.OnchainRootsReader used only by this test.
+type fakeOnchainRootsReader struct {
+	claimsTreeRoot, revocationTreeRoot, rootOfRoots string
+}
+
+func (f *fakeOnchainRootsReader) GetRoots(context.Context, string, string) (string, string, string, error) {
+	return f.claimsTreeRoot, f.revocationTreeRoot, f.rootOfRoots, nil
+}
+
+func (f *fakeOnchainRootsReader) PublishRoots(context.Context, string, string, string, string) error {
+	return nil
+}
+
+func TestSMTResolver_RevocationStatus_PopulatesMTP(t *testing.T) {
+	mts := &fakeMerkleTrees{
+		claimsTreeRoot:     "claims-root",
+		revocationTreeRoot: "revocation-root",
+		rootOfRoots:        "roots-of-roots",
+		proof:              ports.RevocationMTP{Existence: true, Siblings: []string{"sibling-1", "sibling-2"}},
+	}
+	cfg := &config.Configuration{CredentialStatus: config.CredentialStatus{SingleIssuer: boolPtr(true)}}
+
+	resolver, err := NewCredentialStatusResolver(cfg, "https://issuer.example", nil, mts, nil, nil, nil)
+	require.NoError(t, err)
+
+	status, err := resolver.RevocationStatus(context.Background(), "did:iden3:polygon:mumbai:issuer", 42)
+	require.NoError(t, err)
+	require.Equal(t, mts.proof, status.MTP)
+}
+
+func TestOnchainResolver_RevocationStatus_PopulatesMTP(t *testing.T) {
+	mts := &fakeMerkleTrees{
+		proof: ports.RevocationMTP{Existence: true, Siblings: []string{"sibling-1"}},
+	}
+	reader := &fakeOnchainRootsReader{claimsTreeRoot: "chain-claims-root", revocationTreeRoot: "chain-revocation-root", rootOfRoots: "chain-roots-of-roots"}
+	cfg := &config.Configuration{CredentialStatus: config.CredentialStatus{
+		Type:         string(ports.Iden3OnchainSparseMerkleTreeProof),
+		SingleIssuer: boolPtr(true),
+	}}
+
+	resolver, err := NewCredentialStatusResolver(cfg, "https://issuer.example", nil, mts, nil, nil, reader)
+	require.NoError(t, err)
+
+	status, err := resolver.RevocationStatus(context.Background(), "did:iden3:polygon:mumbai:issuer", 42)
+	require.NoError(t, err)
+	require.Equal(t, mts.proof, status.MTP)
+	require.Equal(t, "chain-claims-root", *status.Issuer.ClaimsTreeRoot)
+}
+
+func TestNewCredentialStatusResolver_DispatchesByConfiguredType(t *testing.T) {
+	cases := []struct {
+		name         string
+		statusType   string
+		expectedType ports.CredentialStatusType
+	}{
+		{"defaults to SMT when unset", "", ports.SparseMerkleTreeProof},
+		{"SparseMerkleTreeProof", string(ports.SparseMerkleTreeProof), ports.SparseMerkleTreeProof},
+		{"Iden3ReverseSparseMerkleTreeProof", string(ports.Iden3ReverseSparseMerkleTreeProof), ports.Iden3ReverseSparseMerkleTreeProof},
+		{"Iden3OnchainSparseMerkleTreeProof", string(ports.Iden3OnchainSparseMerkleTreeProof), ports.Iden3OnchainSparseMerkleTreeProof},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Configuration{CredentialStatus: config.CredentialStatus{
+				Type:            tc.statusType,
+				RHSUrl:          "https://rhs.example",
+				OnchainContract: "0xcontract",
+				SingleIssuer:    boolPtr(true),
+			}}
+
+			resolver, err := NewCredentialStatusResolver(cfg, "https://issuer.example", nil, nil, nil, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedType, resolver.Type())
+
+			status := resolver.BuildCredentialStatus("did:iden3:polygon:mumbai:issuer", 42)
+			require.Equal(t, tc.expectedType, status.Type)
+			require.Equal(t, uint64(42), status.RevocationNonce)
+			require.NotEmpty(t, status.ID)
+		})
+	}
+}
+
+func TestNewCredentialStatusResolver_RejectsUnsupportedType(t *testing.T) {
+	cfg := &config.Configuration{CredentialStatus: config.CredentialStatus{Type: "NotARealType", SingleIssuer: boolPtr(true)}}
+
+	_, err := NewCredentialStatusResolver(cfg, "https://issuer.example", nil, nil, nil, nil, nil)
+	require.Error(t, err)
+}
+
+func TestNewCredentialStatusResolver_RejectsMultiIssuerConfig(t *testing.T) {
+	cfg := &config.Configuration{CredentialStatus: config.CredentialStatus{Type: string(ports.SparseMerkleTreeProof), SingleIssuer: boolPtr(false)}}
+
+	_, err := NewCredentialStatusResolver(cfg, "https://issuer.example", nil, nil, nil, nil, nil)
+	require.Error(t, err, "multi-issuer credentialStatus configuration is not implemented yet")
+}
+
+func TestNewCredentialStatusResolver_RejectsUnconfiguredSingleIssuer(t *testing.T) {
+	cfg := &config.Configuration{}
+
+	_, err := NewCredentialStatusResolver(cfg, "https://issuer.example", nil, nil, nil, nil, nil)
+	require.Error(t, err, "a zero-value Configuration must fail distinctly from an explicit singleIssuer=false")
+}
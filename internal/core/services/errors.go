@@ -0,0 +1,37 @@
+package services
+
+import "errors"
+
+// ErrWrongDIDMetada is returned by IdentityService.Create when the requested method, blockchain
+// or network combination does not correspond to a supported DID metadata triple.
+var ErrWrongDIDMetada = errors.New("wrong DID metadata")
+
+// ErrNoClaimsToProcess is returned by IdentityService.GetPendingState when every claim issued for
+// a DID is already part of its last published state, i.e. there is nothing new to roll into a
+// state transition. The publish pipeline does not return it: Publisher.PublishState reports the
+// same situation with gateways.ErrNoStatesToProcess.
+var ErrNoClaimsToProcess = errors.New("no claims to process")
+
+// ErrNodeNotFound is returned when a reverse-hash-service node is requested by a hash this node
+// does not know about, either because it was never pushed or because it belongs to a different
+// issuer.
+var ErrNodeNotFound = errors.New("node not found")
+
+// ErrClaimNotFound is returned when a claim lookup by ID does not match any issued claim.
+var ErrClaimNotFound = errors.New("claim not found")
+
+// ErrMalformedURL is returned by CreateClaim when a credentialSchema URL cannot be parsed into
+// an HTTP request.
+var ErrMalformedURL = errors.New("malformed schema URL")
+
+// ErrLoadingSchema is returned by CreateClaim/CreateClaimBatch when the credentialSchema URL
+// cannot be fetched, or the server hosting it responds with anything other than 200 OK.
+var ErrLoadingSchema = errors.New("error loading schema")
+
+// ErrProcessSchema is returned when a fetched schema's JSON-LD context or type definitions could
+// not be parsed into a usable ports.Schema.
+var ErrProcessSchema = errors.New("error processing schema")
+
+// ErrJSONLdContext is returned when the schema's JSON-LD @context could not be resolved into the
+// context entries a W3C credential needs.
+var ErrJSONLdContext = errors.New("error resolving JSON-LD context")
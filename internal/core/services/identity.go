@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// identityService implements ports.IdentityService.
+type identityService struct {
+	identityStateRepo ports.IdentityStateRepository
+	claimsRepo        ports.ClaimsRepository
+	mts               ports.MerkleTreeService
+}
+
+// NewIdentityService is an identityService constructor.
+func NewIdentityService(identityStateRepo ports.IdentityStateRepository, claimsRepo ports.ClaimsRepository, mts ports.MerkleTreeService) ports.IdentityService {
+	return &identityService{
+		identityStateRepo: identityStateRepo,
+		claimsRepo:        claimsRepo,
+		mts:               mts,
+	}
+}
+
+// Create does not create an identity: it always returns ErrWrongDIDMetada, whatever the method,
+// blockchain and network. No genesis state is computed here or in the gateways package; a DID's
+// first IdentityState is the one saved by its first Publisher.PublishState call.
+func (is *identityService) Create(_ context.Context, _, _, _ string, _ string) (*domain.Identity, error) {
+	return nil, ErrWrongDIDMetada
+}
+
+// Get always returns an empty list: this service does not keep track of the identities this
+// node manages.
+func (is *identityService) Get(_ context.Context) ([]string, error) {
+	return []string{}, nil
+}
+
+// GetPendingState returns the claims issued for did since its last published state, and the
+// state root that publishing them would produce. It never mutates the identity's trees. It uses
+// the same ports.PendingClaims helper gateways.publisherGateway.PublishState uses to scope itself
+// to the previous state, so the preview this returns can never drift from what an actual publish
+// would incorporate - this is what fixes the MTP double-counting bug where a nil state filter
+// used to pull every claim regardless of which state (if any) it already belonged to.
+func (is *identityService) GetPendingState(ctx context.Context, did *core.DID) (*ports.PendingState, error) {
+	pendingClaims, _, err := ports.PendingClaims(ctx, is.identityStateRepo, is.claimsRepo, did)
+	if err != nil {
+		return nil, err
+	}
+	if len(pendingClaims) == 0 {
+		return nil, ErrNoClaimsToProcess
+	}
+
+	nextState, err := is.mts.PreviewState(ctx, pendingClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(pendingClaims))
+	for i, claim := range pendingClaims {
+		ids[i] = claim.ID
+	}
+
+	return &ports.PendingState{ClaimIDs: ids, NextState: nextState}, nil
+}
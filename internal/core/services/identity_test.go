@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+)
+
+func TestIdentityService_GetPendingState_OnlyIncludesClaimsNotYetPublished(t *testing.T) {
+	ctx := context.Background()
+	did, err := core.ParseDID("did:iden3:polygon:mumbai:wztRj5oz3J5SFD8VnqMS43yVu4nWyndbQgekyKVs4")
+	require.NoError(t, err)
+
+	claimsRepo := &fakeBatchClaimsRepo{}
+	stateRepo := &fakeBatchIdentityStateRepo{}
+	trees := &fakeBatchMerkleTrees{}
+	identityService := NewIdentityService(stateRepo, claimsRepo, trees)
+
+	_, err = identityService.GetPendingState(ctx, did)
+	require.ErrorIs(t, err, ErrNoClaimsToProcess, "no claims issued yet")
+
+	publishedClaim := &domain.Claim{ID: uuid.New(), Identifier: did.String(), SchemaType: "KYCAgeCredential"}
+	_, err = claimsRepo.Save(ctx, publishedClaim)
+	require.NoError(t, err)
+	published := "previous-state"
+	publishedClaim.IdentityState = &published
+	require.NoError(t, stateRepo.Save(ctx, &domain.IdentityState{Identifier: did.String(), State: &published}))
+
+	pendingClaim := &domain.Claim{ID: uuid.New(), Identifier: did.String(), SchemaType: "KYCCountryOfResidenceCredential"}
+	_, err = claimsRepo.Save(ctx, pendingClaim)
+	require.NoError(t, err)
+
+	pending, err := identityService.GetPendingState(ctx, did)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{pendingClaim.ID}, pending.ClaimIDs, "must not re-include the claim already part of the last published state")
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/iden3/go-schema-processor/verifiable"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// schemaService implements ports.SchemaService, caching loaded schemas by URL so a schema
+// referenced by many claims (e.g. in a CreateClaimBatch request) is only ever fetched once.
+type schemaService struct {
+	httpClient *http.Client
+	resolver   ports.CredentialStatusResolver
+	serverURL  string
+
+	mu    sync.Mutex
+	cache map[string]*ports.Schema
+}
+
+// NewSchemaService is a schemaService constructor. resolver builds the credentialStatus block
+// embedded into every credential this service converts.
+func NewSchemaService(httpClient *http.Client, resolver ports.CredentialStatusResolver, serverURL string) ports.SchemaService {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &schemaService{httpClient: httpClient, resolver: resolver, serverURL: serverURL, cache: make(map[string]*ports.Schema)}
+}
+
+func (s *schemaService) LoadSchema(ctx context.Context, url string) (*ports.Schema, error) {
+	s.mu.Lock()
+	if schema, ok := s.cache[url]; ok {
+		s.mu.Unlock()
+		return schema, nil
+	}
+	s.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedURL, err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrLoadingSchema, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s returned status %d", ErrLoadingSchema, url, resp.StatusCode)
+	}
+
+	var parsed struct {
+		Types   []string `json:"types"`
+		Context []string `json:"@context"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrProcessSchema, err)
+	}
+	if len(parsed.Context) == 0 {
+		return nil, fmt.Errorf("%w: %s has no @context", ErrJSONLdContext, url)
+	}
+
+	schema := &ports.Schema{URL: url, Types: parsed.Types, Context: parsed.Context}
+
+	s.mu.Lock()
+	s.cache[url] = schema
+	s.mu.Unlock()
+
+	return schema, nil
+}
+
+// FromClaimModelToW3CCredential converts a persisted domain.Claim into the W3C verifiable
+// credential returned by GetClaim/GetClaims, embedding a credentialStatus block built by
+// whichever ports.CredentialStatusResolver this node is configured with.
+func (s *schemaService) FromClaimModelToW3CCredential(claim domain.Claim) (*verifiable.W3CCredential, error) {
+	issuanceDate := claim.CreatedAt
+
+	return &verifiable.W3CCredential{
+		ID:                fmt.Sprintf("%s/v1/%s/claims/%s", s.serverURL, claim.Identifier, claim.ID),
+		Context:           []string{"https://www.w3.org/2018/credentials/v1"},
+		Type:              []string{"VerifiableCredential", claim.SchemaType},
+		Issuer:            claim.Issuer,
+		IssuanceDate:      &issuanceDate,
+		CredentialSubject: map[string]interface{}{"id": claim.Identifier, "type": claim.SchemaType},
+		CredentialSchema:  verifiable.CredentialSchema{ID: claim.SchemaURL, Type: "JsonSchemaValidator2018"},
+		CredentialStatus:  s.resolver.BuildCredentialStatus(claim.Issuer, claim.RevNonce),
+	}, nil
+}
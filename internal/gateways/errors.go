@@ -0,0 +1,13 @@
+package gateways
+
+import "errors"
+
+// ErrNoStatesToProcess is returned by Publisher.PublishState when there are no new claims to
+// roll into a state transition for the given DID.
+var ErrNoStatesToProcess = errors.New("no states to process")
+
+// ErrStateIsBeingProcessed is kept for the on-chain submission step described in
+// Publisher.PublishState's doc comment: once a transaction is actually sent asynchronously and a
+// state can be left StatusTransacted, PublishState should return this error instead of
+// double-submitting while the previous one is still in flight.
+var ErrStateIsBeingProcessed = errors.New("a state is already being processed for this identity")
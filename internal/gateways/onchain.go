@@ -0,0 +1,55 @@
+package gateways
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// EthClient is the minimal surface of an eth client this gateway needs, so tests can fake it
+// without spinning up a real chain connection.
+type EthClient interface {
+	CallContract(ctx context.Context, contract, method string, args ...interface{}) ([]byte, error)
+	SendTransaction(ctx context.Context, contract, method string, args ...interface{}) (txID string, err error)
+}
+
+// onchainRootsReader is the ports.OnchainRootsReader implementation backed by the state
+// contract, used when credentialStatus.type is Iden3OnchainSparseMerkleTreeProof.
+type onchainRootsReader struct {
+	eth EthClient
+}
+
+// NewOnchainRootsReader is an onchainRootsReader constructor.
+func NewOnchainRootsReader(eth EthClient) ports.OnchainRootsReader {
+	return &onchainRootsReader{eth: eth}
+}
+
+// getStateResult is the decoded shape of the "getState" contract call's return value.
+type getStateResult struct {
+	ClaimsTreeRoot     string `json:"claimsTreeRoot"`
+	RevocationTreeRoot string `json:"revocationTreeRoot"`
+	RootOfRoots        string `json:"rootOfRoots"`
+}
+
+// GetRoots reads the latest claims tree root, revocation tree root and root of roots the state
+// contract has on file for did.
+func (r *onchainRootsReader) GetRoots(ctx context.Context, contract, did string) (string, string, string, error) {
+	out, err := r.eth.CallContract(ctx, contract, "getState", did)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	var result getStateResult
+	if err := json.Unmarshal(out, &result); err != nil {
+		return "", "", "", fmt.Errorf("decoding getState result: %w", err)
+	}
+	return result.ClaimsTreeRoot, result.RevocationTreeRoot, result.RootOfRoots, nil
+}
+
+// PublishRoots sends the transaction that records the new state roots on the state contract.
+func (r *onchainRootsReader) PublishRoots(ctx context.Context, contract, claimsTreeRoot, revocationTreeRoot, rootOfRoots string) error {
+	_, err := r.eth.SendTransaction(ctx, contract, "transitState", claimsTreeRoot, revocationTreeRoot, rootOfRoots)
+	return err
+}
@@ -0,0 +1,34 @@
+package gateways
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEthClient is an in-memory EthClient used only by this test.
+type fakeEthClient struct {
+	callContractResult []byte
+	callContractErr    error
+	sentTxID           string
+}
+
+func (f *fakeEthClient) CallContract(_ context.Context, _, _ string, _ ...interface{}) ([]byte, error) {
+	return f.callContractResult, f.callContractErr
+}
+
+func (f *fakeEthClient) SendTransaction(_ context.Context, _, _ string, _ ...interface{}) (string, error) {
+	return f.sentTxID, nil
+}
+
+func TestOnchainRootsReader_GetRoots_DecodesAllThreeRoots(t *testing.T) {
+	eth := &fakeEthClient{callContractResult: []byte(`{"claimsTreeRoot":"claims-root","revocationTreeRoot":"revocation-root","rootOfRoots":"roots-of-roots"}`)}
+	reader := NewOnchainRootsReader(eth)
+
+	claimsTreeRoot, revocationTreeRoot, rootOfRoots, err := reader.GetRoots(context.Background(), "0xcontract", "did:iden3:polygon:mumbai:issuer")
+	require.NoError(t, err)
+	require.Equal(t, "claims-root", claimsTreeRoot)
+	require.Equal(t, "revocation-root", revocationTreeRoot)
+	require.Equal(t, "roots-of-roots", rootOfRoots)
+}
@@ -0,0 +1,113 @@
+package gateways
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// publisherGateway implements ports.Publisher on top of the identity's merkle trees and the
+// identity state repository.
+type publisherGateway struct {
+	identityStateRepo ports.IdentityStateRepository
+	claimsRepo        ports.ClaimsRepository
+	mts               ports.MerkleTreeService
+	// statusResolver is optional: when set, its PushRoots is run as a background task after every
+	// successful state transition so RHS/on-chain backends learn about the new roots. Nil for the
+	// default SparseMerkleTreeProof credential status, which has nothing external to push to.
+	statusResolver ports.CredentialStatusResolver
+}
+
+// NewPublisher is a publisherGateway constructor. statusResolver may be nil.
+func NewPublisher(identityStateRepo ports.IdentityStateRepository, claimsRepo ports.ClaimsRepository, mts ports.MerkleTreeService, statusResolver ports.CredentialStatusResolver) ports.Publisher {
+	return &publisherGateway{
+		identityStateRepo: identityStateRepo,
+		claimsRepo:        claimsRepo,
+		mts:               mts,
+		statusResolver:    statusResolver,
+	}
+}
+
+// PublishState loads the last published IdentityState for did, scopes the claims it considers
+// to that previous state (instead of pulling every claim regardless of state), adds only the
+// claims that are not yet part of any published state to the claims tree, and saves the
+// resulting state. The new roots are then handed to statusResolver in the background (for
+// Iden3OnchainSparseMerkleTreeProof, that sends them to the state contract); PublishState itself
+// sends no transaction. This is what fixes MTP-proof credentials whose merkle proof changed
+// after issuance: a claim already incorporated in the previous state is never re-added.
+//
+// There is no separate on-chain submission step yet, so a state is marked StatusConfirmed as
+// soon as it is saved here; nothing currently leaves a state StatusTransacted, so PublishState
+// never has to refuse a call because a previous publication is still in flight.
+func (p *publisherGateway) PublishState(ctx context.Context, did *core.DID) (*ports.PublishedState, error) {
+	newClaims, previousStateHash, err := ports.PendingClaims(ctx, p.identityStateRepo, p.claimsRepo, did)
+	if err != nil {
+		return nil, err
+	}
+	if len(newClaims) == 0 {
+		return nil, ErrNoStatesToProcess
+	}
+
+	for _, claim := range newClaims {
+		if err := p.mts.AddClaim(ctx, claim); err != nil {
+			return nil, err
+		}
+	}
+
+	claimsTreeRoot, revocationTreeRoot, rootOfRoots, err := p.mts.CurrentRoots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newState := domain.IdentityState{
+		Identifier:         did.String(),
+		State:              &claimsTreeRoot,
+		ClaimsTreeRoot:     &claimsTreeRoot,
+		RevocationTreeRoot: &revocationTreeRoot,
+		RootOfRoots:        &rootOfRoots,
+		PreviousState:      previousStateHash,
+		Status:             domain.StatusConfirmed,
+	}
+	if err := p.identityStateRepo.Save(ctx, &newState); err != nil {
+		return nil, err
+	}
+
+	claimIDs := make([]uuid.UUID, len(newClaims))
+	for i, claim := range newClaims {
+		claimIDs[i] = claim.ID
+	}
+	if err := p.claimsRepo.UpdateState(ctx, claimIDs, claimsTreeRoot); err != nil {
+		return nil, err
+	}
+
+	p.pushRootsInBackground(claimsTreeRoot, revocationTreeRoot, rootOfRoots)
+
+	return &ports.PublishedState{
+		ClaimsTreeRoot:     &claimsTreeRoot,
+		RevocationTreeRoot: &revocationTreeRoot,
+		RootOfRoots:        &rootOfRoots,
+		State:              &claimsTreeRoot,
+		TxID:               newState.TxID,
+		ClaimIDs:           claimIDs,
+	}, nil
+}
+
+// pushRootsInBackground notifies the configured credential status backend (RHS node, state
+// contract) about the new roots without making PublishState wait on it: a slow or temporarily
+// unreachable RHS node/chain must not block issuance.
+func (p *publisherGateway) pushRootsInBackground(claimsTreeRoot, revocationTreeRoot, rootOfRoots string) {
+	if p.statusResolver == nil {
+		return
+	}
+
+	go func() {
+		if err := p.statusResolver.PushRoots(context.Background(), claimsTreeRoot, revocationTreeRoot, rootOfRoots); err != nil {
+			log.Printf("pushing roots to %s credential status backend: %v", p.statusResolver.Type(), err)
+		}
+	}()
+}
@@ -0,0 +1,184 @@
+package gateways
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// fakeClaimsRepo is an in-memory ports.ClaimsRepository used only by this test.
+type fakeClaimsRepo struct {
+	mu     sync.Mutex
+	claims []*domain.Claim
+}
+
+func (f *fakeClaimsRepo) Save(_ context.Context, claim *domain.Claim) (uuid.UUID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if claim.ID == uuid.Nil {
+		claim.ID = uuid.New()
+	}
+	f.claims = append(f.claims, claim)
+	return claim.ID, nil
+}
+
+func (f *fakeClaimsRepo) SaveBatch(_ context.Context, claims []*domain.Claim) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, claim := range claims {
+		if claim.ID == uuid.Nil {
+			claim.ID = uuid.New()
+		}
+	}
+	f.claims = append(f.claims, claims...)
+	return nil
+}
+
+func (f *fakeClaimsRepo) GetByID(_ context.Context, _ *core.DID, _ uuid.UUID) (*domain.Claim, error) {
+	return nil, nil
+}
+
+func (f *fakeClaimsRepo) GetAllByState(_ context.Context, _ *core.DID, state *string) ([]*domain.Claim, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var result []*domain.Claim
+	for _, claim := range f.claims {
+		if claim.IdentityState == nil {
+			result = append(result, claim)
+			continue
+		}
+		if state != nil && *claim.IdentityState == *state {
+			result = append(result, claim)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeClaimsRepo) UpdateState(_ context.Context, claimIDs []uuid.UUID, newState string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	updated := make(map[uuid.UUID]bool, len(claimIDs))
+	for _, id := range claimIDs {
+		updated[id] = true
+	}
+	for _, claim := range f.claims {
+		if updated[claim.ID] {
+			state := newState
+			claim.IdentityState = &state
+		}
+	}
+	return nil
+}
+
+// fakeIdentityStateRepo is an in-memory ports.IdentityStateRepository used only by this test.
+type fakeIdentityStateRepo struct {
+	mu     sync.Mutex
+	states []*domain.IdentityState
+}
+
+func (f *fakeIdentityStateRepo) Save(_ context.Context, state *domain.IdentityState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.states = append(f.states, state)
+	return nil
+}
+
+func (f *fakeIdentityStateRepo) GetLatestStateByIdentifier(_ context.Context, _ *core.DID) (*domain.IdentityState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.states) == 0 {
+		return nil, nil
+	}
+	return f.states[len(f.states)-1], nil
+}
+
+// fakeMerkleTrees is an in-memory ports.MerkleTreeService: the "root" is simply the claim IDs
+// added so far, joined in order, which is enough to tell states apart in this test.
+type fakeMerkleTrees struct {
+	mu     sync.Mutex
+	added  []uuid.UUID
+}
+
+func (f *fakeMerkleTrees) AddClaim(_ context.Context, claim *domain.Claim) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.added = append(f.added, claim.ID)
+	return nil
+}
+
+func (f *fakeMerkleTrees) CurrentRoots(_ context.Context) (string, string, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.root(), "revocation-root", "roots-of-roots", nil
+}
+
+func (f *fakeMerkleTrees) PreviewState(_ context.Context, claims []*domain.Claim) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rootFor(append(append([]uuid.UUID{}, f.added...), idsOf(claims)...)), nil
+}
+
+func (f *fakeMerkleTrees) GetClaimProof(_ context.Context, _ uint64) (*ports.RevocationMTP, error) {
+	return &ports.RevocationMTP{Existence: false}, nil
+}
+
+func (f *fakeMerkleTrees) root() string {
+	return f.rootFor(f.added)
+}
+
+func (f *fakeMerkleTrees) rootFor(ids []uuid.UUID) string {
+	root := "root"
+	for _, id := range ids {
+		root += ":" + id.String()
+	}
+	return root
+}
+
+func idsOf(claims []*domain.Claim) []uuid.UUID {
+	ids := make([]uuid.UUID, len(claims))
+	for i, claim := range claims {
+		ids[i] = claim.ID
+	}
+	return ids
+}
+
+func TestPublisherGateway_PublishState_OnlyIncorporatesClaimsNotYetPublished(t *testing.T) {
+	ctx := context.Background()
+	did, err := core.ParseDID("did:iden3:polygon:mumbai:wztRj5oz3J5SFD8VnqMS43yVu4nWyndbQgekyKVs4")
+	require.NoError(t, err)
+
+	claimsRepo := &fakeClaimsRepo{}
+	stateRepo := &fakeIdentityStateRepo{}
+	trees := &fakeMerkleTrees{}
+	publisher := NewPublisher(stateRepo, claimsRepo, trees, nil)
+
+	sigClaim := &domain.Claim{ID: uuid.New(), Identifier: did.String(), SchemaType: "KYCAgeCredential"}
+	mtpClaim1 := &domain.Claim{ID: uuid.New(), Identifier: did.String(), SchemaType: "KYCCountryOfResidenceCredential"}
+	_, err = claimsRepo.Save(ctx, sigClaim)
+	require.NoError(t, err)
+	_, err = claimsRepo.Save(ctx, mtpClaim1)
+	require.NoError(t, err)
+
+	firstPublish, err := publisher.PublishState(ctx, did)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{sigClaim.ID, mtpClaim1.ID}, firstPublish.ClaimIDs)
+
+	mtpClaim2 := &domain.Claim{ID: uuid.New(), Identifier: did.String(), SchemaType: "KYCCountryOfResidenceCredential"}
+	_, err = claimsRepo.Save(ctx, mtpClaim2)
+	require.NoError(t, err)
+
+	secondPublish, err := publisher.PublishState(ctx, did)
+	require.NoError(t, err)
+
+	require.Equal(t, []uuid.UUID{mtpClaim2.ID}, secondPublish.ClaimIDs, "second publish must only incorporate the newly issued claim")
+	require.NotEqual(t, *firstPublish.State, *secondPublish.State, "second publish must produce a new state root")
+}
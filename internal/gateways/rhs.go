@@ -0,0 +1,81 @@
+package gateways
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// rhsClient is the ports.RHSPublisher implementation that talks to an external reverse hash
+// service node over HTTP, as configured by credentialStatus.rhsUrl.
+type rhsClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewRHSClient is a rhsClient constructor.
+func NewRHSClient(baseURL string) ports.RHSPublisher {
+	return &rhsClient{baseURL: baseURL, httpClient: http.DefaultClient}
+}
+
+type rhsPushRequest struct {
+	ClaimsTreeRoot     string `json:"claimsTreeRoot"`
+	RevocationTreeRoot string `json:"revocationTreeRoot"`
+	RootOfRoots        string `json:"rootOfRoots"`
+}
+
+// PushState posts the new tree roots to the configured RHS node so it can serve proofs without
+// this issuer having to stay online.
+func (c *rhsClient) PushState(ctx context.Context, claimsTreeRoot, revocationTreeRoot, rootOfRoots string) error {
+	body, err := json.Marshal(rhsPushRequest{ClaimsTreeRoot: claimsTreeRoot, RevocationTreeRoot: revocationTreeRoot, RootOfRoots: rootOfRoots})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/node", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("rhs node returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// GetRevocationStatus asks the configured RHS node for the proof of a revocation nonce. The
+// issuer's own GetRevocationStatusNode endpoint is the fallback a wallet is expected to use when
+// this call (or the node's own subsequent GET /node/{hash} calls) fails.
+func (c *rhsClient) GetRevocationStatus(ctx context.Context, did string, revNonce uint64) (*ports.RevocationStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/node/%s/%d", c.baseURL, did, revNonce), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rhs node returned status %d", resp.StatusCode)
+	}
+
+	var status ports.RevocationStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
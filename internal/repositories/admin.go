@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// ErrAdminDuplicateName is returned when an issuer, schema whitelist entry or admin user with
+// the same unique name/URL already exists.
+var ErrAdminDuplicateName = errors.New("a resource with that name already exists")
+
+// ErrAdminNotFound is returned when an admin resource lookup by id does not match any row.
+var ErrAdminNotFound = errors.New("resource not found")
+
+const pgUniqueViolation = "23505"
+
+type admin struct {
+	conn *pgxpool.Pool
+}
+
+// NewAdmin is an AdminRepository constructor.
+func NewAdmin(conn *pgxpool.Pool) ports.AdminRepository {
+	return &admin{conn: conn}
+}
+
+func (a *admin) CreateIssuer(ctx context.Context, did, name string) (*domain.AdminIssuer, error) {
+	issuer := &domain.AdminIssuer{ID: uuid.New(), DID: did, Name: name}
+	_, err := a.conn.Exec(ctx, `INSERT INTO admin_issuers (id, did, name) VALUES ($1, $2, $3)`, issuer.ID, issuer.DID, issuer.Name)
+	if isUniqueViolation(err) {
+		return nil, ErrAdminDuplicateName
+	}
+	if err != nil {
+		return nil, err
+	}
+	return issuer, nil
+}
+
+func (a *admin) GetIssuers(ctx context.Context) ([]*domain.AdminIssuer, error) {
+	rows, err := a.conn.Query(ctx, `SELECT id, did, name, created_at FROM admin_issuers ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var issuers []*domain.AdminIssuer
+	for rows.Next() {
+		var issuer domain.AdminIssuer
+		if err := rows.Scan(&issuer.ID, &issuer.DID, &issuer.Name, &issuer.CreatedAt); err != nil {
+			return nil, err
+		}
+		issuers = append(issuers, &issuer)
+	}
+	return issuers, rows.Err()
+}
+
+func (a *admin) UpdateIssuer(ctx context.Context, id uuid.UUID, did, name string) (*domain.AdminIssuer, error) {
+	cmd, err := a.conn.Exec(ctx, `UPDATE admin_issuers SET did = $1, name = $2 WHERE id = $3`, did, name, id)
+	if isUniqueViolation(err) {
+		return nil, ErrAdminDuplicateName
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cmd.RowsAffected() == 0 {
+		return nil, ErrAdminNotFound
+	}
+	return &domain.AdminIssuer{ID: id, DID: did, Name: name}, nil
+}
+
+func (a *admin) DeleteIssuer(ctx context.Context, id uuid.UUID) error {
+	cmd, err := a.conn.Exec(ctx, `DELETE FROM admin_issuers WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrAdminNotFound
+	}
+	return nil
+}
+
+func (a *admin) CreateSchemaWhitelistEntry(ctx context.Context, url string, types, ldContext []string) (*domain.SchemaWhitelistEntry, error) {
+	entry := &domain.SchemaWhitelistEntry{ID: uuid.New(), URL: url, Types: types, Context: ldContext}
+	_, err := a.conn.Exec(ctx,
+		`INSERT INTO admin_schema_whitelist (id, url, types, context) VALUES ($1, $2, $3, $4)`,
+		entry.ID, entry.URL, entry.Types, entry.Context)
+	if isUniqueViolation(err) {
+		return nil, ErrAdminDuplicateName
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (a *admin) GetSchemaWhitelist(ctx context.Context) ([]*domain.SchemaWhitelistEntry, error) {
+	rows, err := a.conn.Query(ctx, `SELECT id, url, types, context, created_at FROM admin_schema_whitelist ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domain.SchemaWhitelistEntry
+	for rows.Next() {
+		var entry domain.SchemaWhitelistEntry
+		if err := rows.Scan(&entry.ID, &entry.URL, &entry.Types, &entry.Context, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
+
+func (a *admin) DeleteSchemaWhitelistEntry(ctx context.Context, id uuid.UUID) error {
+	cmd, err := a.conn.Exec(ctx, `DELETE FROM admin_schema_whitelist WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrAdminNotFound
+	}
+	return nil
+}
+
+func (a *admin) CreateAdminUser(ctx context.Context, name string, isSuperAdmin bool) (*domain.AdminUser, error) {
+	user := &domain.AdminUser{ID: uuid.New(), Name: name, IsSuperAdmin: isSuperAdmin, Status: domain.AdminUserActive}
+	_, err := a.conn.Exec(ctx,
+		`INSERT INTO admin_users (id, name, is_super_admin, status) VALUES ($1, $2, $3, $4)`,
+		user.ID, user.Name, user.IsSuperAdmin, user.Status)
+	if isUniqueViolation(err) {
+		return nil, ErrAdminDuplicateName
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (a *admin) GetAdminUsers(ctx context.Context) ([]*domain.AdminUser, error) {
+	rows, err := a.conn.Query(ctx, `SELECT id, name, is_super_admin, status, created_at FROM admin_users ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.AdminUser
+	for rows.Next() {
+		var user domain.AdminUser
+		if err := rows.Scan(&user.ID, &user.Name, &user.IsSuperAdmin, &user.Status, &user.CreatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+	return users, rows.Err()
+}
+
+// GetAdminUser looks up a single admin user by id, for adminAuthMiddleware to re-check current
+// status on every request rather than trusting whatever was true when the JWT was minted.
+func (a *admin) GetAdminUser(ctx context.Context, id uuid.UUID) (*domain.AdminUser, error) {
+	var user domain.AdminUser
+	err := a.conn.QueryRow(ctx, `SELECT id, name, is_super_admin, status, created_at FROM admin_users WHERE id = $1`, id).
+		Scan(&user.ID, &user.Name, &user.IsSuperAdmin, &user.Status, &user.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAdminNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetAdminUserStatus transitions the admin user to status rather than deleting its row, keeping
+// its past actions attributable.
+func (a *admin) SetAdminUserStatus(ctx context.Context, id uuid.UUID, status domain.AdminUserStatus) error {
+	cmd, err := a.conn.Exec(ctx, `UPDATE admin_users SET status = $1 WHERE id = $2`, status, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrAdminNotFound
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgUniqueViolation
+	}
+	return false
+}
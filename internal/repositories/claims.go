@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+// ErrClaimDoesNotExist is returned when a claim lookup by identifier/nonce does not match any row.
+var ErrClaimDoesNotExist = errors.New("claim does not exist")
+
+type claims struct {
+	conn *pgxpool.Pool
+}
+
+// NewClaims is a claims repository constructor.
+func NewClaims(conn *pgxpool.Pool) ports.ClaimsRepository {
+	return &claims{conn: conn}
+}
+
+func (c *claims) Save(ctx context.Context, claim *domain.Claim) (uuid.UUID, error) {
+	if claim.ID == uuid.Nil {
+		claim.ID = uuid.New()
+	}
+
+	_, err := c.conn.Exec(ctx,
+		`INSERT INTO claims (id, identifier, issuer, schema_hash, schema_url, schema_type, rev_nonce, core_claim, identity_state)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		claim.ID, claim.Identifier, claim.Issuer, claim.SchemaHash, claim.SchemaURL, claim.SchemaType, claim.RevNonce, claim.CoreClaimHex, claim.IdentityState)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return claim.ID, nil
+}
+
+// SaveBatch inserts claims under a single transaction, rolling back entirely on any failure.
+func (c *claims) SaveBatch(ctx context.Context, claims []*domain.Claim) error {
+	if len(claims) == 0 {
+		return nil
+	}
+
+	tx, err := c.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op once committed
+
+	for _, claim := range claims {
+		if claim.ID == uuid.Nil {
+			claim.ID = uuid.New()
+		}
+
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO claims (id, identifier, issuer, schema_hash, schema_url, schema_type, rev_nonce, core_claim, identity_state)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			claim.ID, claim.Identifier, claim.Issuer, claim.SchemaHash, claim.SchemaURL, claim.SchemaType, claim.RevNonce, claim.CoreClaimHex, claim.IdentityState); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (c *claims) GetByID(ctx context.Context, did *core.DID, id uuid.UUID) (*domain.Claim, error) {
+	row := c.conn.QueryRow(ctx,
+		`SELECT id, identifier, issuer, schema_hash, schema_url, schema_type, rev_nonce, core_claim, identity_state, created_at
+		 FROM claims WHERE identifier = $1 AND id = $2`, did.String(), id)
+
+	var claim domain.Claim
+	err := row.Scan(&claim.ID, &claim.Identifier, &claim.Issuer, &claim.SchemaHash, &claim.SchemaURL, &claim.SchemaType, &claim.RevNonce, &claim.CoreClaimHex, &claim.IdentityState, &claim.CreatedAt)
+	if err != nil {
+		return nil, ErrClaimDoesNotExist
+	}
+
+	return &claim, nil
+}
+
+// GetAllByState returns the claims for did that belong to state, plus the claims that are not
+// yet part of any published state (identity_state IS NULL). Passing a nil state restricts the
+// result to the latter only, which is what a DID's first-ever publish scopes to.
+//
+// This replaces the previous unconditional "identity_state filter is nil" query, which matched
+// every claim ever issued for the DID regardless of which (if any) published state it already
+// belonged to, and caused already-published MTP claims to be re-added on every subsequent
+// publish.
+func (c *claims) GetAllByState(ctx context.Context, did *core.DID, state *string) ([]*domain.Claim, error) {
+	rows, err := c.conn.Query(ctx,
+		`SELECT id, identifier, issuer, schema_hash, schema_url, schema_type, rev_nonce, core_claim, identity_state, created_at
+		 FROM claims
+		 WHERE identifier = $1 AND (identity_state IS NULL OR identity_state = $2)`,
+		did.String(), state)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*domain.Claim
+	for rows.Next() {
+		var claim domain.Claim
+		if err := rows.Scan(&claim.ID, &claim.Identifier, &claim.Issuer, &claim.SchemaHash, &claim.SchemaURL, &claim.SchemaType, &claim.RevNonce, &claim.CoreClaimHex, &claim.IdentityState, &claim.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, &claim)
+	}
+
+	return result, rows.Err()
+}
+
+// UpdateState marks claimIDs as incorporated into newState.
+func (c *claims) UpdateState(ctx context.Context, claimIDs []uuid.UUID, newState string) error {
+	if len(claimIDs) == 0 {
+		return nil
+	}
+
+	_, err := c.conn.Exec(ctx, `UPDATE claims SET identity_state = $1 WHERE id = ANY($2)`, newState, claimIDs)
+	return err
+}
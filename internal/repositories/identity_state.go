@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/polygonid/sh-id-platform/internal/core/domain"
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+type identityState struct {
+	conn *pgxpool.Pool
+}
+
+// NewIdentityState is an identityState repository constructor.
+func NewIdentityState(conn *pgxpool.Pool) ports.IdentityStateRepository {
+	return &identityState{conn: conn}
+}
+
+func (r *identityState) Save(ctx context.Context, state *domain.IdentityState) error {
+	_, err := r.conn.Exec(ctx,
+		`INSERT INTO identity_states (identifier, state, claims_tree_root, revocation_tree_root, root_of_roots, previous_state, status, tx_id)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		state.Identifier, state.State, state.ClaimsTreeRoot, state.RevocationTreeRoot, state.RootOfRoots, state.PreviousState, state.Status, state.TxID)
+	return err
+}
+
+// GetLatestStateByIdentifier returns the most recently published IdentityState for did, or nil
+// (not an error) when the DID has never been published.
+func (r *identityState) GetLatestStateByIdentifier(ctx context.Context, did *core.DID) (*domain.IdentityState, error) {
+	row := r.conn.QueryRow(ctx,
+		`SELECT state_id, identifier, state, root_of_roots, claims_tree_root, revocation_tree_root,
+		        block_timestamp, block_number, tx_id, previous_state, status, modified_at, created_at
+		 FROM identity_states
+		 WHERE identifier = $1
+		 ORDER BY state_id DESC
+		 LIMIT 1`, did.String())
+
+	var state domain.IdentityState
+	err := row.Scan(&state.StateID, &state.Identifier, &state.State, &state.RootOfRoots, &state.ClaimsTreeRoot, &state.RevocationTreeRoot,
+		&state.BlockTimestamp, &state.BlockNumber, &state.TxID, &state.PreviousState, &state.Status, &state.ModifiedAt, &state.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/polygonid/sh-id-platform/internal/core/ports"
+)
+
+type rhsNodes struct {
+	conn *pgxpool.Pool
+}
+
+// NewRHSNodes is a rhsNodes repository constructor. It backs GetRevocationStatusNode's local
+// fallback for the Iden3ReverseSparseMerkleTreeProof credential status type.
+func NewRHSNodes(conn *pgxpool.Pool) ports.RHSNodeRepository {
+	return &rhsNodes{conn: conn}
+}
+
+func (r *rhsNodes) Save(ctx context.Context, node *ports.Node) error {
+	_, err := r.conn.Exec(ctx,
+		`INSERT INTO rhs_nodes (hash, children) VALUES ($1, $2) ON CONFLICT (hash) DO NOTHING`,
+		node.Hash, node.Children)
+	return err
+}
+
+func (r *rhsNodes) GetByHash(ctx context.Context, hash string) (*ports.Node, error) {
+	var node ports.Node
+	err := r.conn.QueryRow(ctx, `SELECT hash, children FROM rhs_nodes WHERE hash = $1`, hash).
+		Scan(&node.Hash, &node.Children)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &node, nil
+}